@@ -27,18 +27,28 @@ type Application struct {
 
 	// ----- stuff from letterboxd
 
-	Username     string      // username on letterboxd
-	ApiKey       string      // TMDB api key
-	ListHeaders  []*FilmList // lists that belong to user on letterboxd (without scrapped films)
-	Watchlist    FilmsSet    // users letterboxd watchlist
-	WatchedFilms FilmsSet    // users list of watched films on letterboxd
+	Username     string              // username on letterboxd
+	ApiKey       string              // TMDB api key
+	ListHeaders  []*FilmList         // lists that belong to user on letterboxd (without scrapped films)
+	Watchlist    FilmsSet            // users letterboxd watchlist
+	WatchedFilms FilmsSet            // users list of watched films on letterboxd
+	WatchDates   map[int]time.Time   // most recent date each film was watched (from diary)
+	Ratings      map[int]*FilmRating // users ratings, likes, and rewatches of watched films
 
 	// ----- tracked by app
 
 	NWQueue         NextWatch
-	TrackedLists    map[string]*FilmList // lists tracked in this program; urls are keys
-	FilmStore       FilmStore            // central structure that stores local film information
-	UserDataChecked time.Time            // last time watchlist, watched films, etc. were checked
+	Groups          []*Group                // groups of members with their own queues
+	Queues          []*Queue                // named queues with their own sources and filters
+	TrackedLists    map[string]*FilmList    // lists tracked in this program; urls are keys
+	Members         map[string]*Member      // other letterboxd members whose lists were browsed; usernames are keys
+	FilmStore       FilmStore               // central structure that stores local film information
+	UserDataChecked time.Time               // last time watchlist, watched films, etc. were checked
+	WatchedFullSync time.Time               // last time all watched films were scraped (not just recent)
+	History         []ListDiff              // changes found when refreshing tracked lists (oldest first)
+	unreported      []ListDiff              // changes not yet shown to the user
+	updateErrs      []error                 // problems found while updating not yet shown to the user
+	pendingDiary    map[string][]DiaryEntry // diary entries of films not known yet by url (see applyPendingDiary)
 
 	// ----- tracked processes
 	DiscordRPC DiscordRPC
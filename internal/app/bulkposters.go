@@ -0,0 +1,145 @@
+package app
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const posterDownloadWorkers = 4 // posters downloaded at the same time
+
+var ErrPosterExists = errors.New("poster already downloaded")
+
+// Outcome of downloading one film's poster in bulk.
+type PosterResult struct {
+	Film Film
+	Path string // where the poster was (or already had been) saved
+	Err  error  // ErrPosterExists if the poster was skipped
+}
+
+type posterJob struct {
+	i    int // index of film
+	film Film
+}
+
+// Downloads posters (see DownloadPoster) for all films concurrently, skipping
+// films whose poster has already been downloaded. Films are looked up by the
+// download workers as well, so this can run in the background.
+//
+// progress (if not nil) is called after each film is finished with the number
+// of films done so far. Results are in the same order as films.
+func (app *Application) DownloadPosters(films []*Film, progress func(done, total int)) []PosterResult {
+	results := make([]PosterResult, len(films))
+	jobs := make(chan posterJob)
+	finished := make(chan struct{})
+	var wg sync.WaitGroup
+	for range posterDownloadWorkers {
+		wg.Go(func() {
+			for j := range jobs {
+				results[j.i] = app.downloadFilmPoster(j.film)
+				finished <- struct{}{}
+			}
+		})
+	}
+	go func() {
+		for i, film := range films {
+			jobs <- posterJob{i: i, film: *film}
+		}
+		close(jobs)
+		wg.Wait()
+		close(finished)
+	}()
+	done := 0
+	for range finished {
+		done++
+		if progress != nil {
+			progress(done, len(films))
+		}
+	}
+	return results
+}
+
+// Looks up film and downloads its poster (unless it was already downloaded).
+func (app *Application) downloadFilmPoster(film Film) PosterResult {
+	fr, err := app.FilmStore.lookupCopy(film)
+	if err != nil {
+		return PosterResult{Film: film, Err: err}
+	}
+	path, err := downloadNewPoster(fr)
+	return PosterResult{Film: film, Path: path, Err: err}
+}
+
+// Downloads poster unless a file already exists where it would be saved.
+func downloadNewPoster(fr FilmRecord) (string, error) {
+	img, err := posterImage(fr)
+	if err != nil {
+		return "", err
+	}
+	fName := imageFileName(fr, img.Kind, path.Ext(img.Path))
+	if _, err := os.Stat(fName); err == nil {
+		return fName, fmt.Errorf("%w, %s", ErrPosterExists, fName)
+	}
+	return DownloadImage(fr, img)
+}
+
+// Counts posters that were downloaded and skipped, returning the results of
+// those that failed.
+func SummarizePosters(results []PosterResult) (downloaded, skipped int, failed []PosterResult) {
+	for _, r := range results {
+		switch {
+		case r.Err == nil:
+			downloaded++
+		case errors.Is(r.Err, ErrPosterExists):
+			skipped++
+		default:
+			failed = append(failed, r)
+		}
+	}
+	return downloaded, skipped, failed
+}
+
+// Films posters can be downloaded for, given the name of where they are from:
+// "watchlist", "queue" (the Next Watch queue), the name of a group or named
+// queue, or the name or url of a tracked list (ignoring case).
+func (app *Application) PosterSource(name string) ([]*Film, error) {
+	switch strings.ToLower(name) {
+	case "watchlist":
+		return sortedFilms(app.Watchlist), nil
+	case "queue":
+		return app.NWQueue.Films(), nil
+	}
+	for _, g := range app.Groups {
+		if strings.EqualFold(g.Name, name) {
+			return g.NextWatch.Films(), nil
+		}
+	}
+	for _, q := range app.Queues {
+		if strings.EqualFold(q.Name, name) {
+			return q.NextWatch.Films(), nil
+		}
+	}
+	for url, fl := range app.TrackedLists {
+		if strings.EqualFold(fl.Name, name) || url == name {
+			return fl.Films, nil
+		}
+	}
+	return nil, fmt.Errorf("%w, %s", ErrListNotTracked, name)
+}
+
+// Films in set ordered by title (then year), so downloads happen in a
+// predictable order.
+func sortedFilms(set FilmsSet) []*Film {
+	films := make([]*Film, 0, len(set))
+	for _, f := range set {
+		films = append(films, f)
+	}
+	slices.SortFunc(films, func(a, b *Film) int {
+		return cmp.Or(strings.Compare(a.Title, b.Title), cmp.Compare(a.Year, b.Year))
+	})
+	return films
+}
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+func TestDownloadPosters(t *testing.T) {
+	resetOffline(t)
+	prevUrl, prevDirs, prevPosters := tmdbImageUrl, Config.Directories, Config.Posters
+	t.Cleanup(func() { tmdbImageUrl, Config.Directories, Config.Posters = prevUrl, prevDirs, prevPosters })
+	Config.Directories.Posters = t.TempDir()
+	Config.Posters = posterConfig{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/original/missing.jpg" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("poster"))
+	}))
+	t.Cleanup(server.Close)
+	tmdbImageUrl = server.URL + "/"
+
+	record := func(id int, title, posterPath string) *FilmRecord {
+		fr := &FilmRecord{Film: Film{LBxdID: id, Title: title, Year: 2000}, Checked: time.Now()}
+		if posterPath != "" {
+			fr.Details = &tmdb.MovieDetails{PosterPath: posterPath}
+		}
+		return fr
+	}
+	store := map[int]*FilmRecord{
+		1: record(1, "New", "/new.jpg"),
+		2: record(2, "Existing", "/existing.jpg"),
+		3: record(3, "Missing", "/missing.jpg"),
+		4: record(4, "No Poster", ""),
+	}
+	app := &Application{FilmStore: FilmStore{Films: store}}
+	existing := filepath.Join(Config.Directories.Posters, "existing_2000.jpg")
+	if err := os.WriteFile(existing, []byte("old"), 0o644); err != nil {
+		t.Fatalf("could not write existing poster, %s", err)
+	}
+
+	films := []*Film{&store[1].Film, &store[2].Film, &store[3].Film, &store[4].Film}
+	calls, last := 0, 0
+	results := app.DownloadPosters(films, func(done, total int) {
+		calls++
+		if done != last+1 || total != len(films) {
+			t.Errorf("unexpected progress %d/%d after %d", done, total, last)
+		}
+		last = done
+	})
+	if calls != len(films) {
+		t.Errorf("want progress called %d times, got %d", len(films), calls)
+	}
+	wantErrs := []error{nil, ErrPosterExists, ErrRetreivingPoster, ErrMissingPosterPath}
+	for i, r := range results {
+		if r.Film.LBxdID != films[i].LBxdID {
+			t.Errorf("result %d is for %s, want %s", i, r.Film, films[i])
+		}
+		if !errors.Is(r.Err, wantErrs[i]) || (wantErrs[i] == nil) != (r.Err == nil) {
+			t.Errorf("result %d: want error %v, got %v", i, wantErrs[i], r.Err)
+		}
+	}
+	if content, err := os.ReadFile(existing); err != nil || string(content) != "old" {
+		t.Errorf("existing poster was overwritten (content %q, error %v)", content, err)
+	}
+	if content, err := os.ReadFile(results[0].Path); err != nil || string(content) != "poster" {
+		t.Errorf("new poster was not saved (content %q, error %v)", content, err)
+	}
+	downloaded, skipped, failed := SummarizePosters(results)
+	if downloaded != 1 || skipped != 1 || len(failed) != 2 {
+		t.Errorf("want 1 downloaded, 1 skipped, and 2 failed, got %d, %d, and %d", downloaded, skipped, len(failed))
+	}
+}
+
+func TestPosterSource(t *testing.T) {
+	a, b, c := &Film{LBxdID: 1, Title: "B"}, &Film{LBxdID: 2, Title: "A"}, &Film{LBxdID: 3, Title: "C"}
+	stacks := make([][]*Film, NumberOfStacks+1)
+	stacks[0] = []*Film{a}
+	for i := range NumberOfStacks {
+		stacks[i+1] = make([]*Film, StackSize) // empty spots are skipped
+	}
+	app := &Application{
+		Watchlist:    FilmsSet{1: a, 2: b},
+		TrackedLists: map[string]*FilmList{"https://letterboxd.com/u/list/l/": {Name: "My List", Films: []*Film{c}}},
+		Queues:       []*Queue{{Name: "Horror", NextWatch: NextWatch{Stacks: stacks}}},
+	}
+	testCases := []struct {
+		name    string
+		want    []*Film
+		wantErr error
+	}{
+		{name: "watchlist", want: []*Film{b, a}},
+		{name: "my list", want: []*Film{c}},
+		{name: "https://letterboxd.com/u/list/l/", want: []*Film{c}},
+		{name: "horror", want: []*Film{a}},
+		{name: "other", wantErr: ErrListNotTracked},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			films, err := app.PosterSource(test.name)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("expected error %v, got %v", test.wantErr, err)
+			}
+			if len(films) != len(test.want) {
+				t.Fatalf("want %d films, got %d", len(test.want), len(films))
+			}
+			for i := range films {
+				if films[i] != test.want[i] {
+					t.Errorf("film %d: want %s, got %s", i, test.want[i], films[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+var ErrWaitingOnPredecessors = errors.New("waiting on earlier films")
+
+// Collection (i.e., franchise or series) a film belongs to on TMDB.
+type Collection struct {
+	ID    int              // tmdb collection id
+	Name  string           // name of collection (e.g., "The Lord of the Rings Collection")
+	Parts []CollectionPart // films in collection sorted by release date
+}
+
+// Film in a collection.
+type CollectionPart struct {
+	TMDBID      int
+	Title       string
+	ReleaseDate string // YYYY-MM-DD (empty if unknown)
+}
+
+// Queries TMDB for the collection with the given id. Parts are sorted by
+// release date (undated films last).
+func TMDBCollection(id int) (*Collection, error) {
+	if TMDBClient == nil {
+		return nil, ErrNoAPI
+	}
+	if err := checkOnline(); err != nil {
+		return nil, err
+	}
+	details, err := TMDBClient.GetCollectionDetails(id, localeOptions(nil, false))
+	if err != nil {
+		return nil, fmt.Errorf("%w, collection with id %d, %w", ErrFailedTMDBLookup, id, err)
+	}
+	c := &Collection{ID: int(details.ID), Name: details.Name}
+	for _, p := range details.Parts {
+		if p.Adult {
+			continue
+		}
+		c.Parts = append(c.Parts, CollectionPart{TMDBID: int(p.ID), Title: p.Title, ReleaseDate: p.ReleaseDate})
+	}
+	slices.SortFunc(c.Parts, func(a, b CollectionPart) int {
+		return compareReleaseDates(a.ReleaseDate, b.ReleaseDate, a.Title, b.Title)
+	})
+	return c, nil
+}
+
+// Compares release dates (YYYY-MM-DD) for sorting, putting undated films last
+// and breaking ties by title.
+func compareReleaseDates(dateA, dateB, titleA, titleB string) int {
+	switch {
+	case dateA == "" && dateB != "":
+		return 1
+	case dateA != "" && dateB == "":
+		return -1
+	case dateA != dateB:
+		return strings.Compare(dateA, dateB)
+	}
+	return strings.Compare(titleA, titleB)
+}
+
+func (c *Collection) results() []tmdb.MovieResult {
+	results := make([]tmdb.MovieResult, len(c.Parts))
+	for i, p := range c.Parts {
+		results[i] = tmdb.MovieResult{ID: int64(p.TMDBID), Title: p.Title, ReleaseDate: p.ReleaseDate}
+	}
+	return results
+}
+
+// Released films in the collection that come before the film with the given
+// TMDB id and have not been watched.
+func (c *Collection) UnwatchedPredecessors(tmdbID int, m *FilmMatcher, watched FilmsSet) []CollectionPart {
+	idx := slices.IndexFunc(c.Parts, func(p CollectionPart) bool { return p.TMDBID == tmdbID })
+	if idx == -1 {
+		return nil
+	}
+	now := time.Now()
+	preds := make([]CollectionPart, 0)
+	for _, p := range c.Parts[:idx] {
+		date, err := time.Parse("2006-01-02", p.ReleaseDate)
+		if err != nil || date.After(now) || p.ReleaseDate == c.Parts[idx].ReleaseDate {
+			continue
+		}
+		r := tmdb.MovieResult{ID: int64(p.TMDBID), Title: p.Title, ReleaseDate: p.ReleaseDate}
+		if f, ok := m.Match(r); !ok || !watched.InSet(&f) {
+			preds = append(preds, p)
+		}
+	}
+	return preds
+}
+
+// Number of released films in the film's collection and how many of them the
+// user has watched. Returns false if the film is not part of a collection.
+func (app *Application) CollectionProgress(fr *FilmRecord) (watched, released int, ok bool) {
+	if fr.Collection == nil {
+		return 0, 0, false
+	}
+	watched, released = app.WatchedProgress(fr.Collection.results(), app.NewFilmMatcher())
+	return watched, released, true
+}
+
+// Checks if a film should be held back because earlier films in its
+// collection have not been watched (only if sequels_in_order is set in the
+// config). Only saved film records are checked, so this never queries TMDB.
+func holdBackSequel(store *FilmStore, film Film, m func() *FilmMatcher, watched FilmsSet) bool {
+	if !Config.Features.SequelsInOrder {
+		return false
+	}
+	fr, ok := store.saved(film.LBxdID)
+	if !ok || fr.Collection == nil {
+		return false
+	}
+	return len(fr.Collection.UnwatchedPredecessors(fr.TMDBID, m(), watched)) > 0
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func testCollection() *Collection {
+	return &Collection{
+		ID:   1,
+		Name: "The Test Collection",
+		Parts: []CollectionPart{
+			{TMDBID: 1, Title: "Part One", ReleaseDate: "2001-12-19"},
+			{TMDBID: 2, Title: "Part Two", ReleaseDate: "2002-12-18"},
+			{TMDBID: 3, Title: "Part Three", ReleaseDate: "2003-12-17"},
+			{TMDBID: 4, Title: "Part Four", ReleaseDate: "2999-01-01"},
+		},
+	}
+}
+
+func TestUnwatchedPredecessors(t *testing.T) {
+	partOne := Film{LBxdID: 10, Title: "Part One", Year: 2001}
+	testCases := []struct {
+		name    string
+		tmdbID  int
+		watched FilmsSet
+		want    []string
+	}{
+		{name: "first part", tmdbID: 1, watched: FilmsSet{}, want: []string{}},
+		{name: "none watched", tmdbID: 3, watched: FilmsSet{}, want: []string{"Part One", "Part Two"}},
+		{name: "some watched", tmdbID: 3, watched: FilmsSet{partOne.LBxdID: &partOne}, want: []string{"Part Two"}},
+		{name: "not in collection", tmdbID: 9, watched: FilmsSet{}},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			store := &FilmStore{Films: make(map[int]*FilmRecord)}
+			preds := testCollection().UnwatchedPredecessors(test.tmdbID, newFilmMatcher(store, test.watched), test.watched)
+			var got []string
+			if preds != nil {
+				got = make([]string, len(preds))
+				for i, p := range preds {
+					got[i] = p.Title
+				}
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestCollectionProgress(t *testing.T) {
+	partOne := Film{LBxdID: 10, Title: "Part One", Year: 2001}
+	app := &Application{
+		WatchedFilms: FilmsSet{partOne.LBxdID: &partOne},
+		Watchlist:    FilmsSet{},
+		FilmStore:    FilmStore{Films: make(map[int]*FilmRecord)},
+	}
+	watched, released, ok := app.CollectionProgress(&FilmRecord{TMDBID: 2, Collection: testCollection()})
+	if !ok || watched != 1 || released != 3 {
+		t.Errorf("want 1 of 3 watched, got %d of %d (ok %t)", watched, released, ok)
+	}
+	if _, _, ok := app.CollectionProgress(&FilmRecord{}); ok {
+		t.Error("expected no progress for film without a collection")
+	}
+}
+
+func TestNextWatchSequelsInOrder(t *testing.T) {
+	resetOffline(t)
+	prev := Config.Features.SequelsInOrder
+	t.Cleanup(func() { Config.Features.SequelsInOrder = prev })
+	partOne := Film{LBxdID: 100, Title: "Part One", Year: 2001}
+	testCases := []struct {
+		name    string
+		enabled bool
+		watched FilmsSet
+		wantErr error
+	}{
+		{name: "predecessor unwatched", enabled: true, watched: FilmsSet{}, wantErr: ErrNotEnoughFilms},
+		{name: "predecessor watched", enabled: true, watched: FilmsSet{partOne.LBxdID: &partOne}},
+		{name: "option disabled", watched: FilmsSet{}},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			Config.Features.SequelsInOrder = test.enabled
+			total := NumberOfStacks*StackSize + 1
+			app := Application{Watchlist: make(FilmsSet, total), WatchedFilms: test.watched}
+			for id := 1; id <= total; id++ {
+				app.Watchlist[id] = &Film{LBxdID: id}
+			}
+			seedFilmStore(t, &app.FilmStore, app.Watchlist)
+			sequel := app.FilmStore.Films[total]
+			sequel.TMDBID, sequel.Collection = 2, testCollection()
+			_, err := app.MakeNextWatch()
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("expected error %v, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFilmListNextWatchSequelsInOrder(t *testing.T) {
+	prev := Config.Features.SequelsInOrder
+	t.Cleanup(func() { Config.Features.SequelsInOrder = prev })
+	Config.Features.SequelsInOrder = true
+	partOne := &Film{LBxdID: 1, Title: "Part One", Year: 2001}
+	partTwo := &Film{LBxdID: 2, Title: "Part Two", Year: 2002}
+	store := &FilmStore{Films: map[int]*FilmRecord{
+		partTwo.LBxdID: {Film: *partTwo, TMDBID: 2, Collection: testCollection()},
+	}}
+	fl := FilmList{Name: "Sequels", Films: []*Film{partTwo}, watched: FilmsSet{}, store: store}
+	if _, err := fl.NextWatch(); !errors.Is(err, ErrWaitingOnPredecessors) {
+		t.Fatalf("expected error %v, got %v", ErrWaitingOnPredecessors, err)
+	}
+	fl.Films = append(fl.Films, partOne)
+	for range 10 { // list is shuffled, so check more than once
+		fl.NextFilm = nil
+		if got, err := fl.NextWatch(); err != nil || got.LBxdID != partOne.LBxdID {
+			t.Fatalf("expected %s, got %s (err %v)", partOne, got, err)
+		}
+	}
+	fl.Ordered = true
+	fl.NextFilm = nil
+	if got, err := fl.NextWatch(); err != nil || got.LBxdID != partTwo.LBxdID {
+		t.Errorf("expected ordered list to suggest %s, got %s (err %v)", partTwo, got, err)
+	}
+}
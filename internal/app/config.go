@@ -14,19 +14,76 @@ type config struct {
 	Username    string           `toml:"username"`
 	ApiKey      string           `toml:"api_key"`
 	Features    featuresConfig   `toml:"features"`
+	Cache       cacheConfig      `toml:"cache"`
+	TMDB        tmdbConfig       `toml:"tmdb"`
+	Groups      []groupConfig    `toml:"groups"`
+	Queues      []queueConfig    `toml:"queues"`
 	Appearance  appearanceConfig `toml:"appearance"`
 	Keybinds    keybindConfig    `toml:"keybinds"`
 	Directories directoryConfig  `toml:"directories"`
+	Posters     posterConfig     `toml:"posters"`
 }
 
 type featuresConfig struct {
-	DisableDiscordRPC bool `toml:"discord_rpc"`
-	AlwaysIncludeTMDB bool `toml:"always_include_tmdb"`
+	DisableDiscordRPC bool   `toml:"discord_rpc"`
+	AlwaysIncludeTMDB bool   `toml:"always_include_tmdb"`
+	SequelsInOrder    bool   `toml:"sequels_in_order"` // never suggest a sequel before the films preceding it
+	TrailerPlayer     string `toml:"trailer_player"`   // command trailers are opened with (browser if empty)
+}
+
+type cacheConfig struct {
+	FilmExpireDays       int  `toml:"film_expire_days"`
+	UserDataExpireHours  int  `toml:"user_data_expire_hours"`
+	StaleWhileRevalidate bool `toml:"stale_while_revalidate"`
+}
+
+type tmdbConfig struct {
+	Region   string   `toml:"region"`   // ISO 3166-1 country code (e.g., "US")
+	Language string   `toml:"language"` // ISO 639-1 language code, optionally with region (e.g., "fr-FR")
+	Services []string `toml:"services"` // streaming services the user subscribes to
+}
+
+type groupConfig struct {
+	Name        string   `toml:"name"`
+	Members     []string `toml:"members"`      // usernames of other members
+	Rule        string   `toml:"rule"`         // union or intersection
+	ExcludeSeen bool     `toml:"exclude_seen"` // exclude films any member has watched
+}
+
+type queueConfig struct {
+	Name       string   `toml:"name"`
+	Source     string   `toml:"source"`      // watchlist or url of list
+	Lists      []string `toml:"lists"`       // urls of lists to pick from
+	MinRuntime int      `toml:"min_runtime"` // in minutes
+	MaxRuntime int      `toml:"max_runtime"` // in minutes
+	MinYear    uint     `toml:"min_year"`
+	MaxYear    uint     `toml:"max_year"`
+	Genres     []string `toml:"genres"`         // film must have one of these genres
+	MyServices bool     `toml:"on_my_services"` // film must be streaming on one of the user's services
+}
+
+func (qc queueConfig) filters() QueueFilters {
+	return QueueFilters{
+		MinRuntime: qc.MinRuntime,
+		MaxRuntime: qc.MaxRuntime,
+		MinYear:    qc.MinYear,
+		MaxYear:    qc.MaxYear,
+		Genres:     qc.Genres,
+		MyServices: qc.MyServices,
+	}
+}
+
+type posterConfig struct {
+	Kind     string `toml:"kind"`     // poster, backdrop, or logo
+	Size     string `toml:"size"`     // tmdb image size (e.g., "w780" or "original")
+	Filename string `toml:"filename"` // filename template (e.g., "{title}_{year}")
+	Language string `toml:"language"` // ISO 639-1 code of language images are picked in
 }
 
 type appearanceConfig struct {
-	Border string       `toml:"border"` // rounded, normal, double
-	Colors colorPalette `toml:"colors"`
+	Border  string       `toml:"border"`  // rounded, normal, double
+	Posters string       `toml:"posters"` // auto, kitty, sixel, blocks, or none
+	Colors  colorPalette `toml:"colors"`
 }
 
 type colorPalette struct {
@@ -45,6 +102,8 @@ type keybindConfig struct {
 	Right       []string `toml:"right"`
 	Up          []string `toml:"up"`
 	Down        []string `toml:"down"`
+	ScrollUp    []string `toml:"scroll_up"`
+	ScrollDown  []string `toml:"scroll_down"`
 	MoveLeft    []string `toml:"move_left"`
 	MoveRight   []string `toml:"move_right"`
 	MoveUp      []string `toml:"move_up"`
@@ -54,11 +113,15 @@ type keybindConfig struct {
 	Update      []string `toml:"update"`
 	StopWatch   []string `toml:"stop_watch"`
 	About       []string `toml:"about"`
+	History     []string `toml:"history"`
+	SwitchQueue []string `toml:"switch_queue"`
+	Posters     []string `toml:"posters"`
 }
 
 type directoryConfig struct {
 	Data    string `toml:"data"`
 	Posters string `toml:"posters"`
+	Cache   string `toml:"cache"`
 }
 
 var (
@@ -0,0 +1,202 @@
+package app
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gocolly/colly"
+)
+
+// Entry from a user's Letterboxd diary, as listed in their RSS feed. The feed
+// does not include letterboxd film ids, so Film.LBxdID is always zero.
+type DiaryEntry struct {
+	Film
+	TMDBID      int       // tmdb id number
+	WatchedDate time.Time // date the film was watched
+	Rating      float64   // star rating out of 5 (zero if not rated)
+	Rewatch     bool      // film had been watched before
+}
+
+// raw RSS item; only the fields we care about
+type diaryItem struct {
+	Link         string `xml:"link"`
+	WatchedDate  string `xml:"watchedDate"`
+	Rewatch      string `xml:"rewatch"`
+	FilmTitle    string `xml:"filmTitle"`
+	FilmYear     string `xml:"filmYear"`
+	MemberRating string `xml:"memberRating"`
+	MovieID      string `xml:"movieId"`
+}
+
+type diaryFeed struct {
+	Items []diaryItem `xml:"channel>item"`
+}
+
+// Scrapes recent diary entries from a user's RSS feed (newest first).
+func ScrapeDiaryFeed(username string) ([]DiaryEntry, error) {
+	if err := checkOnline(); err != nil {
+		return nil, err
+	}
+	feedUrl, err := url.JoinPath(LetterboxdUrl, username, "rss")
+	if err != nil {
+		return nil, fmt.Errorf("problem joining url parts, %w", err)
+	}
+	var body []byte
+	c := colly.NewCollector()
+	status := attachScrapeLogger(c, "diary feed")
+	c.OnResponse(func(r *colly.Response) {
+		body = r.Body
+	})
+	if err := c.Visit(feedUrl + "/"); err != nil {
+		return nil, fmt.Errorf("problem trying to visit url %s, %w", feedUrl, status.wrap(err))
+	}
+	return parseDiaryFeed(bytes.NewReader(body))
+}
+
+// Parses diary entries from a Letterboxd RSS feed. Items that are not diary
+// entries (e.g., lists) are skipped.
+func parseDiaryFeed(r io.Reader) ([]DiaryEntry, error) {
+	var feed diaryFeed
+	if err := xml.NewDecoder(r).Decode(&feed); err != nil {
+		return nil, fmt.Errorf("%w, could not parse diary feed, %w", ErrBadScrape, err)
+	}
+	entries := make([]DiaryEntry, 0, len(feed.Items))
+	for _, item := range feed.Items {
+		if item.WatchedDate == "" || item.FilmTitle == "" {
+			continue
+		}
+		entry, err := item.entry()
+		if err != nil {
+			log.Printf("skipping diary entry %s, %s", item.Link, err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (item diaryItem) entry() (DiaryEntry, error) {
+	var entry DiaryEntry
+	var err error
+	if entry.Url, err = diaryFilmUrl(item.Link); err != nil {
+		return entry, err
+	}
+	if entry.WatchedDate, err = time.Parse("2006-01-02", item.WatchedDate); err != nil {
+		return entry, fmt.Errorf("invalid watched date, %w", err)
+	}
+	entry.Title = item.FilmTitle
+	if year, err := strconv.Atoi(item.FilmYear); err == nil {
+		entry.Year = uint(year)
+	}
+	if id, err := strconv.Atoi(item.MovieID); err == nil {
+		entry.TMDBID = id
+	}
+	if rating, err := strconv.ParseFloat(item.MemberRating, 64); err == nil {
+		entry.Rating = rating
+	}
+	entry.Rewatch = strings.EqualFold(item.Rewatch, "yes")
+	return entry, nil
+}
+
+// Converts a diary entry link (e.g., https://letterboxd.com/<user>/film/<slug>/1/)
+// to the film's url (i.e., https://letterboxd.com/film/<slug>/).
+func diaryFilmUrl(link string) (string, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", fmt.Errorf("%w, %w", ErrInvalidUrl, err)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i, part := range parts {
+		if part == "film" && i+1 < len(parts) {
+			return LetterboxdUrl + "/film/" + parts[i+1] + "/", nil
+		}
+	}
+	return "", fmt.Errorf("%w, %s is not a diary entry", ErrInvalidUrl, link)
+}
+
+// Updates watched films, watch dates, and ratings from the user's diary feed.
+// This is much faster than scraping the user's films, but only covers recent
+// activity. The feed lacks letterboxd ids, so entries for films nw doesn't know
+// about yet are kept until the watched films sync finds them.
+func (app *Application) updateDiary() error {
+	log.Print("updating diary")
+	entries, err := ScrapeDiaryFeed(app.Username)
+	if err != nil {
+		return err
+	}
+	app.ingestDiary(entries)
+	return nil
+}
+
+// Applies diary entries for films in the film store, keeping the rest for
+// applyPendingDiary.
+func (app *Application) ingestDiary(entries []DiaryEntry) {
+	films := app.FilmStore.films()
+	byUrl := make(map[string]Film, len(films))
+	for _, f := range films {
+		byUrl[f.Url] = f
+	}
+	app.pendingDiary = make(map[string][]DiaryEntry)
+	added := make([]*Film, 0)
+	for _, entry := range slices.Backward(entries) { // oldest first so newest rating wins
+		film, ok := byUrl[entry.Url]
+		if !ok {
+			app.pendingDiary[entry.Url] = append(app.pendingDiary[entry.Url], entry)
+			continue
+		}
+		app.applyDiaryEntry(film, entry)
+		if !app.WatchedFilms.InSet(&film) && !containsFilm(added, film) {
+			added = append(added, &film)
+		}
+	}
+	if len(added) > 0 {
+		app.applyWatchedDiff(added, nil)
+	}
+	if len(app.pendingDiary) > 0 {
+		log.Printf("%d diary films not known yet, waiting for watched films sync", len(app.pendingDiary))
+	}
+}
+
+// Applies diary entries kept for films that were not known when the diary was
+// read, now that they have been found (and registered) by the watched films
+// sync.
+func (app *Application) applyPendingDiary(films []*Film) {
+	for _, f := range films {
+		for _, entry := range app.pendingDiary[f.Url] {
+			app.applyDiaryEntry(*f, entry)
+		}
+		delete(app.pendingDiary, f.Url)
+	}
+}
+
+// Sets watch date, rating, and TMDB id of a film in the film store from a
+// diary entry.
+func (app *Application) applyDiaryEntry(film Film, entry DiaryEntry) {
+	if app.WatchDates == nil {
+		app.WatchDates = make(map[int]time.Time)
+	}
+	if entry.WatchedDate.After(app.WatchDates[film.LBxdID]) {
+		app.WatchDates[film.LBxdID] = entry.WatchedDate
+	}
+	app.updateRatingFromDiary(film.LBxdID, FilmRating{Stars: halfStars(entry.Rating), Rewatched: entry.Rewatch})
+	if entry.TMDBID != 0 {
+		app.FilmStore.setTMDBID(film.LBxdID, entry.TMDBID)
+	}
+}
+
+func containsFilm(films []*Film, film Film) bool {
+	for _, f := range films {
+		if f.LBxdID == film.LBxdID {
+			return true
+		}
+	}
+	return false
+}
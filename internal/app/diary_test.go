@@ -0,0 +1,133 @@
+package app
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestParseDiaryFeed(t *testing.T) {
+	f, err := os.Open("testdata/diary.rss")
+	if err != nil {
+		t.Fatalf("failed to open fixture: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	entries, err := parseDiaryFeed(f)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []DiaryEntry{
+		{
+			Film:        Film{Url: "https://letterboxd.com/film/past-lives/", Title: "Past Lives", Year: 2023},
+			TMDBID:      666277,
+			WatchedDate: time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+			Rating:      4.5,
+			Rewatch:     false,
+		},
+		{
+			Film:        Film{Url: "https://letterboxd.com/film/barbie/", Title: "Barbie", Year: 2023},
+			TMDBID:      346698,
+			WatchedDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Rating:      0,
+			Rewatch:     true,
+		},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %d entries, got %d", len(expected), len(entries))
+	}
+	for i, want := range expected {
+		if entries[i] != want {
+			t.Errorf("entry %d: want=%+v\n!= got=%+v", i, want, entries[i])
+		}
+	}
+}
+
+func TestDiaryFilmUrl(t *testing.T) {
+	testCases := []struct {
+		name    string
+		link    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "first watch",
+			link: "https://letterboxd.com/nwtest/film/past-lives/",
+			want: "https://letterboxd.com/film/past-lives/",
+		},
+		{
+			name: "rewatch",
+			link: "https://letterboxd.com/nwtest/film/barbie/1/",
+			want: "https://letterboxd.com/film/barbie/",
+		},
+		{
+			name:    "list",
+			link:    "https://letterboxd.com/nwtest/list/favourite-films/",
+			wantErr: true,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := diaryFilmUrl(test.link)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Fatalf("expected %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestIngestDiary(t *testing.T) {
+	known := &Film{LBxdID: 1, Url: "https://letterboxd.com/film/past-lives/", Title: "Past Lives", Year: 2023}
+	unknown := &Film{LBxdID: 2, Url: "https://letterboxd.com/film/aftersun/", Title: "Aftersun", Year: 2022}
+	app := &Application{
+		Watchlist:    FilmsSet{known.LBxdID: known},
+		WatchedFilms: FilmsSet{},
+		TrackedLists: map[string]*FilmList{},
+		FilmStore:    FilmStore{Films: map[int]*FilmRecord{}},
+	}
+	app.FilmStore.RegisterSet(app.Watchlist)
+	watched := time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)
+	app.ingestDiary([]DiaryEntry{
+		{Film: Film{Url: known.Url}, TMDBID: 666277, WatchedDate: watched},
+		{Film: Film{Url: known.Url}, TMDBID: 666277, WatchedDate: watched.AddDate(0, 0, -10)},
+		{Film: Film{Url: unknown.Url}, TMDBID: 965150, Rating: 4.5, WatchedDate: watched},
+	})
+	if !app.WatchedFilms.InSet(known) {
+		t.Fatalf("expected known film to be marked watched")
+	}
+	if len(app.WatchedFilms) != 1 {
+		t.Fatalf("expected only known film to be added, got %d films", len(app.WatchedFilms))
+	}
+	if got := app.WatchDates[known.LBxdID]; !got.Equal(watched) {
+		t.Fatalf("expected watch date %s, got %s", watched, got)
+	}
+	record := app.FilmStore.Films[known.LBxdID]
+	if record.TMDBID != 666277 {
+		t.Fatalf("expected TMDB id to be filled, got %d", record.TMDBID)
+	}
+	if record.NRefs != 2 {
+		t.Fatalf("expected 2 refs (watchlist and watched), got %d", record.NRefs)
+	}
+
+	app.applyWatchedDiff([]*Film{unknown}, nil) // found by watched films sync
+	if record, ok := app.FilmStore.Films[unknown.LBxdID]; !ok || record.TMDBID != 965150 {
+		t.Fatalf("expected pending entry to fill TMDB id, got %+v", record)
+	}
+	if got := app.WatchDates[unknown.LBxdID]; !got.Equal(watched) {
+		t.Fatalf("expected pending watch date %s, got %s", watched, got)
+	}
+	if r, ok := app.Rating(*unknown); !ok || r.Stars != 9 {
+		t.Fatalf("expected pending rating of 9 half stars, got %+v", r)
+	}
+	if len(app.pendingDiary) != 0 {
+		t.Fatalf("expected pending entries to be cleared, got %v", app.pendingDiary)
+	}
+}
@@ -0,0 +1,96 @@
+package app
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Known pages used to check that the scrapers still work.
+const (
+	doctorListUrl   = "https://letterboxd.com/oscars/list/the-96th-academy-award-nominees-for-best/"
+	doctorListFilms = 10
+	doctorFilmUrl   = "https://letterboxd.com/film/dancer-in-the-dark/"
+	doctorFilmTMDB  = 16
+)
+
+// Result of probing one scraper.
+type DoctorCheck struct {
+	Name   string // what was checked
+	Detail string // summary of what was scraped
+	Err    error  // nil if the check passed
+}
+
+// Probes each scraper against known Letterboxd pages (and the user's own pages
+// if username is not empty), so that markup changes can be diagnosed without
+// starting the TUI.
+func Doctor(username string) []DoctorCheck {
+	checks := []DoctorCheck{
+		doctorCheck("film list", func() (string, error) {
+			fl, err := ScrapeFilmList(doctorListUrl)
+			if err != nil {
+				return "", err
+			}
+			if fl.Name == "" || fl.NumFilms != doctorListFilms {
+				return "", &ScrapeError{Kind: ErrMarkupChanged, Url: doctorListUrl,
+					Err: fmt.Errorf("expected %d films, got %d (name %q)", doctorListFilms, fl.NumFilms, fl.Name)}
+			}
+			return fmt.Sprintf("%d films from %q", fl.NumFilms, fl.Name), nil
+		}),
+		doctorCheck("film tmdb id", func() (string, error) {
+			id, err := ScrapeFilmID(doctorFilmUrl)
+			if err != nil {
+				return "", err
+			}
+			if id != doctorFilmTMDB {
+				return "", &ScrapeError{Kind: ErrMarkupChanged, Url: doctorFilmUrl,
+					Err: fmt.Errorf("expected tmdb id %d, got %d", doctorFilmTMDB, id)}
+			}
+			return fmt.Sprintf("tmdb id %d", id), nil
+		}),
+		doctorCheck("film from tmdb id", func() (string, error) {
+			film, err := ScrapeFilmFromTMDB(doctorFilmTMDB)
+			if err != nil {
+				return "", err
+			}
+			if film.Url != doctorFilmUrl {
+				return "", &ScrapeError{Kind: ErrMarkupChanged, Url: doctorFilmUrl,
+					Err: fmt.Errorf("expected film at %s, got %s", doctorFilmUrl, film.Url)}
+			}
+			return film.String(), nil
+		}),
+	}
+	if username == "" {
+		return checks
+	}
+	return append(checks,
+		doctorCheck("user lists", func() (string, error) {
+			lists, err := ScrapeUserLists(username)
+			return fmt.Sprintf("%d lists", len(lists)), err
+		}),
+		doctorCheck("watchlist", func() (string, error) {
+			watchlist, err := retrieveWatchlist(username)
+			return fmt.Sprintf("%d films", len(watchlist)), err
+		}),
+		doctorCheck("watched films", func() (string, error) {
+			filmsUrl, err := url.JoinPath(LetterboxdUrl, username, "films", "by", "date")
+			if err != nil {
+				return "", fmt.Errorf("problem joining url parts, %w", err)
+			}
+			fl, err := scrapeFilmList(filmsUrl, func([]*Film) bool { return true })
+			return fmt.Sprintf("%d films on first page, %d rated or liked", len(fl.Films), len(fl.ratings)), err
+		}),
+		doctorCheck("diary feed", func() (string, error) {
+			entries, err := ScrapeDiaryFeed(username)
+			return fmt.Sprintf("%d entries", len(entries)), err
+		}),
+		doctorCheck("diary page", func() (string, error) {
+			ratings, err := ScrapeDiaryPage(username)
+			return fmt.Sprintf("%d films", len(ratings)), err
+		}),
+	)
+}
+
+func doctorCheck(name string, probe func() (string, error)) DoctorCheck {
+	detail, err := probe()
+	return DoctorCheck{Name: name, Detail: detail, Err: err}
+}
@@ -1,6 +1,10 @@
 package app
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"strings"
+)
 
 // Struct storing data for film,
 type Film struct {
@@ -23,3 +27,30 @@ func (fs FilmsSet) InSet(film *Film) bool {
 	_, ok := fs[film.LBxdID]
 	return ok
 }
+
+// User's rating, like, and rewatch status for a watched film.
+type FilmRating struct {
+	Stars     uint // rating in half stars from 1 to 10 (zero if not rated)
+	Liked     bool // user liked the film
+	Rewatched bool // user has logged a rewatch of the film
+}
+
+// Star rating as text (e.g., ★★★½); empty if not rated.
+func (r FilmRating) StarString() string {
+	if r.Stars == 0 {
+		return ""
+	}
+	stars := strings.Repeat("★", int(r.Stars/2))
+	if r.Stars%2 == 1 {
+		stars += "½"
+	}
+	return stars
+}
+
+// Converts a star rating out of 5 (e.g., 3.5) to half stars.
+func halfStars(rating float64) uint {
+	if rating <= 0 || rating > 5 {
+		return 0
+	}
+	return uint(math.Round(rating * 2))
+}
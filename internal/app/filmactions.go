@@ -1,15 +1,16 @@
 package app
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -42,40 +43,84 @@ func (d DiscordRPC) Watching() bool {
 	return d.stop != nil
 }
 
-// Downloads poster given a film record containing a PosterPath in its details.
-// Returns the path the poster was saved to.
+// Downloads the film's image (a poster, backdrop, or logo, as set in the
+// config). Returns the path the image was saved to.
 func DownloadPoster(fr FilmRecord) (string, error) {
-	if fr.Details.PosterPath == "" {
-		return "", fmt.Errorf("%w for film %s", ErrMissingPosterPath, fr.Title)
+	img, err := posterImage(fr)
+	if err != nil {
+		return "", err
 	}
-	resp, err := http.Get(PosterPathPrefix + fr.Details.PosterPath)
-	defer func() { _ = resp.Body.Close() }()
+	return DownloadImage(fr, img)
+}
+
+// Image the poster action downloads. The image TMDB shows for the film is used
+// unless a language is configured or another kind is wanted.
+func posterImage(fr FilmRecord) (FilmImage, error) {
+	kind, err := ConfigImageKind()
 	if err != nil {
-		return "", fmt.Errorf("%w for film %s, %w", ErrRetreivingPoster, fr.Title, err)
+		return FilmImage{}, err
 	}
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("%w for film %s, status code %d != %d", ErrRetreivingPoster, fr.Title, resp.StatusCode, http.StatusOK)
+	if kind == ImagePoster && imageLanguage() == "" {
+		if fr.Details == nil || fr.Details.PosterPath == "" {
+			return FilmImage{}, fmt.Errorf("%w for film %s", ErrMissingPosterPath, fr.Title)
+		}
+		return FilmImage{Kind: ImagePoster, Path: fr.Details.PosterPath}, nil
 	}
-	content, err := io.ReadAll(resp.Body)
+	images, err := FilmImages(fr.TMDBID, kind)
 	if err != nil {
-		return "", err
+		return FilmImage{}, fmt.Errorf("%w for film %s, %w", ErrRetreivingPoster, fr.Title, err)
+	}
+	if len(images) == 0 {
+		return FilmImage{}, fmt.Errorf("%w, no %s for film %s", ErrMissingPosterPath, kind, fr.Title)
 	}
-	path := posterFileName(fr.Film)
-	return path, os.WriteFile(path, content, 0o644)
+	return images[0], nil
 }
 
-var nonAlphanumericRegex = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+// Downloads image in the configured size, saving it in the posters directory
+// under the configured filename. Returns the path the image was saved to.
+func DownloadImage(fr FilmRecord, img FilmImage) (string, error) {
+	size, err := configImageSize(img.Kind)
+	if err != nil {
+		return "", err
+	}
+	content, err := fetchImage(tmdbImageUrl + size + img.Path)
+	if err != nil {
+		return "", fmt.Errorf("%w for film %s, %w", ErrRetreivingPoster, fr.Title, err)
+	}
+	fName := imageFileName(fr, img.Kind, path.Ext(img.Path))
+	if err := os.MkdirAll(filepath.Dir(fName), 0o755); err != nil {
+		return "", err
+	}
+	return fName, os.WriteFile(fName, content, 0o644)
+}
 
-func posterFileName(f Film) string {
-	cleanTitle := nonAlphanumericRegex.ReplaceAllString(f.Title, "")
-	fName := fmt.Sprintf("%s_%d.jpg", strings.ToLower(cleanTitle), f.Year)
+// Path image is saved to, filling in the filename template from the config
+// ({title}, {year}, {tmdb_id}, {director}, and {kind}).
+func imageFileName(fr FilmRecord, kind ImageKind, ext string) string {
+	director := ""
+	if credits := fr.Credits(); len(credits) > 0 && credits[0].Role == "Director" {
+		director = cleanFileName(credits[0].Name)
+	}
+	name := strings.NewReplacer(
+		"{title}", cleanFileName(fr.Title),
+		"{year}", strconv.Itoa(int(fr.Year)),
+		"{tmdb_id}", strconv.Itoa(fr.TMDBID),
+		"{director}", director,
+		"{kind}", string(kind),
+	).Replace(cmp.Or(Config.Posters.Filename, defaultImageFilename))
 	posterBaseDir := Config.Directories.Posters
 	if posterBaseDir == "" {
 		posterBaseDir = xdg.UserDirs.Download
 	}
-	return filepath.Join(posterBaseDir, fName)
+	return filepath.Join(posterBaseDir, name+ext)
+}
+
+func cleanFileName(s string) string {
+	return strings.ToLower(nonAlphanumericRegex.ReplaceAllString(s, ""))
 }
 
+var nonAlphanumericRegex = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
 func (app *Application) StartDiscordRPC(fr FilmRecord) error {
 	if app.DiscordRPC.Watching() {
 		app.StopDiscordRPC()
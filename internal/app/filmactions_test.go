@@ -1,8 +1,12 @@
 package app
 
 import (
+	"encoding/json"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
 )
 
 func TestPosterFileName(t *testing.T) {
@@ -19,9 +23,42 @@ func TestPosterFileName(t *testing.T) {
 
 	for _, tt := range tests {
 		f := Film{Title: tt.title, Year: tt.year}
-		got := posterFileName(f)
+		got := imageFileName(FilmRecord{Film: f}, ImagePoster, ".jpg")
 		if filepath.Base(got) != tt.expected {
-			t.Errorf("posterFileName(%v) = %v, want to end with %v", tt.title, got, tt.expected)
+			t.Errorf("imageFileName(%v) = %v, want to end with %v", tt.title, got, tt.expected)
+		}
+	}
+}
+
+func TestImageFileNameTemplate(t *testing.T) {
+	prev := Config.Posters
+	t.Cleanup(func() { Config.Posters = prev })
+	var credits tmdb.MovieCredits
+	if err := json.Unmarshal([]byte(`{"crew": [{"id": 1, "name": "Lars von Trier", "job": "Director"}]}`), &credits); err != nil {
+		t.Fatalf("could not unmarshal credits, %s", err)
+	}
+	fr := FilmRecord{
+		Film:    Film{Title: "Dancer in the Dark", Year: 2000},
+		TMDBID:  16,
+		Details: &tmdb.MovieDetails{MovieCreditsAppend: &tmdb.MovieCreditsAppend{}},
+	}
+	fr.Details.Credits.MovieCredits = &credits
+	tests := []struct {
+		template string
+		kind     ImageKind
+		ext      string
+		expected string
+	}{
+		{"", ImagePoster, ".jpg", "dancerinthedark_2000.jpg"},
+		{"{tmdb_id}-{kind}", ImageBackdrop, ".jpg", "16-backdrop.jpg"},
+		{"{director}_{title}", ImageLogo, ".png", "larsvontrier_dancerinthedark.png"},
+		{"{director}/{year}", ImagePoster, ".jpg", filepath.Join("larsvontrier", "2000.jpg")},
+	}
+	for _, tt := range tests {
+		Config.Posters.Filename = tt.template
+		got := imageFileName(fr, tt.kind, tt.ext)
+		if !strings.HasSuffix(got, string(filepath.Separator)+tt.expected) {
+			t.Errorf("imageFileName with template %q = %v, want to end with %v", tt.template, got, tt.expected)
 		}
 	}
 }
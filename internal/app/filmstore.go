@@ -4,30 +4,44 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 	"time"
 
 	tmdb "github.com/cyruzin/golang-tmdb"
 )
 
-const filmExpireTime = 30 * 24 * time.Hour // film records are deleted after 30 days
+const (
+	defaultFilmExpireTime = 30 * 24 * time.Hour // film records are refreshed after 30 days
+	ratingExpireFactor    = 3                   // letterboxd ratings change slowly, so are scraped every third refresh
+)
 
 // Keeps track of all films that are currently in memory so we do not duplicate
 // scraping TMDB ids and TMDB api calls.
 type FilmStore struct {
-	Films map[int]*FilmRecord // Film records index by letterboxd ids
+	Films    map[int]*FilmRecord // Film records index by letterboxd ids
+	Deferred map[int]Film        // films to retrieve once connectivity returns (kept across sessions)
+
+	mu        sync.Mutex       // guards films and their records, which are also updated in the background
+	refreshes chan FilmRefresh // background refreshes are sent here, nil until received (see Refreshes)
 }
 
 type FilmRecord struct {
 	Film
 	TMDBID      int                // tmdb id number
 	Details     *tmdb.MovieDetails // film details from tmdb
-	ReleaseDate time.Time          // release date (according to tmdb)
+	ReleaseDate time.Time          // release date in configured region (or primary release date, according to tmdb)
 	Watched     bool               // film is recorded as watched
+	Collection  *Collection        // collection the film belongs to (nil if none)
+	LBxdRating  float64            // average rating on letterboxd out of 5 (0 if unknown)
+	RatingTime  time.Time          // last time the letterboxd rating was scraped (even if there was none)
+	Locale      string             // tmdb language and region details were fetched for (see currentLocale)
 
 	// These fields are only exported so they can be marshaled. Please don't mutate.
 
 	Checked time.Time // last time details were checked
 	NRefs   uint      // number of list references
+
+	refreshing bool // details are being refreshed in the background
 }
 
 // Add film list to be tracked. Films in registered lists will be saved/stored
@@ -63,22 +77,117 @@ func (fs *FilmStore) DeregisterSet(filmSet map[int]*Film) {
 
 // Get cached film record, retrieve if necessary
 //
+// If stale-while-revalidate is enabled in the config (and refreshes are being
+// received, see Refreshes), expired records are returned immediately and
+// refreshed in the background. When offline, expired records are returned as
+// is and their retrieval is deferred until connectivity returns.
+//
 // Returns error if it needs to retrieve details and fails.
 func (fs *FilmStore) Lookup(film Film) (*FilmRecord, error) {
-	if f, ok := fs.Films[film.LBxdID]; ok && time.Since(f.Checked) < filmExpireTime {
-		return f, nil
+	if fr, ok, err := fs.lookupSaved(film); ok || err != nil {
+		return fr, err
 	}
-	if err := fs.retrieve(film); err != nil {
+	fr, err := fs.retrieve(film)
+	if err == nil {
+		return fr, nil
+	}
+	if !isNetworkError(err) {
 		return nil, err
 	}
-	if f, ok := fs.Films[film.LBxdID]; ok {
-		return f, nil
+	goOffline(err)
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.deferRetrieve(film)
+	if fr.Details != nil { // stale details are better than none
+		return fr, nil
+	}
+	return nil, fmt.Errorf("%w, %w", ErrOffline, err)
+}
+
+// Saved record for film, if it can be served without retrieving its details
+// first (ok is false if it can't).
+func (fs *FilmStore) lookupSaved(film Film) (fr *FilmRecord, ok bool, err error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fr, found := fs.Films[film.LBxdID]
+	if found && !fr.Expired() {
+		return fr, true, nil
+	}
+	stale := found && fr.Details != nil
+	switch {
+	case Offline():
+		fs.deferRetrieve(film)
+		if stale {
+			return fr, true, nil
+		}
+		return nil, false, fmt.Errorf("%w, no saved details for %s", ErrOffline, film)
+	case stale && Config.Cache.StaleWhileRevalidate && fs.refreshes != nil:
+		fs.revalidate(fr)
+		return fr, true, nil
+	}
+	return nil, false, nil
+}
+
+// Films with known TMDB ids, indexed by TMDB id.
+func (fs *FilmStore) TMDBIndex() map[int]Film {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	index := make(map[int]Film, len(fs.Films))
+	for _, fr := range fs.Films {
+		if fr.TMDBID != 0 {
+			index[fr.TMDBID] = fr.Film
+		}
+	}
+	return index
+}
+
+// Looks up film (see Lookup), returning a copy of its record that can be used
+// while the store's record is updated elsewhere.
+func (fs *FilmStore) lookupCopy(film Film) (FilmRecord, error) {
+	fr, err := fs.Lookup(film)
+	if err != nil {
+		return FilmRecord{}, err
+	}
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return *fr, nil
+}
+
+// Copy of film's saved record, without retrieving anything.
+func (fs *FilmStore) saved(id int) (FilmRecord, bool) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if fr, ok := fs.Films[id]; ok {
+		return *fr, true
+	}
+	return FilmRecord{}, false
+}
+
+// Films with saved records.
+func (fs *FilmStore) films() []Film {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	films := make([]Film, 0, len(fs.Films))
+	for _, fr := range fs.Films {
+		films = append(films, fr.Film)
+	}
+	return films
+}
+
+// Sets film's TMDB id if it is not known yet, which saves scraping the id
+// again when details are looked up.
+func (fs *FilmStore) setTMDBID(id, tmdbID int) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if fr, ok := fs.Films[id]; ok && fr.TMDBID == 0 {
+		fr.TMDBID = tmdbID
 	}
-	panic("film record not found after adding it even though Add() returned err=nil")
 }
 
 // Clear film records that are either not referenced or too old.
 func (fs *FilmStore) Clean() {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
 	for id, fr := range fs.Films {
 		if fr.NRefs == 0 {
 			delete(fs.Films, id)
@@ -88,6 +197,8 @@ func (fs *FilmStore) Clean() {
 
 // register a film to be tracked (or increase ref counter if already registered)
 func (fs *FilmStore) register(film Film) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
 	if fr, ok := fs.Films[film.LBxdID]; ok {
 		fr.NRefs++
 	} else {
@@ -103,6 +214,8 @@ func (fs *FilmStore) register(film Film) {
 
 // stop tracking an instance of a film (decrease ref counter)
 func (fs *FilmStore) deregister(film Film) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
 	fr, ok := fs.Films[film.LBxdID]
 	if !ok {
 		panic(fmt.Sprintf("trying to deregister %s, but it has not been registered", film))
@@ -113,9 +226,11 @@ func (fs *FilmStore) deregister(film Film) {
 	fr.NRefs--
 }
 
-// retrieve film details. This involves scrapping letterboxd for TMDB id and
-// then querying TMDB for details.
-func (fs *FilmStore) retrieve(film Film) error {
+// retrieve film details and store them in the film's record, which is returned
+// (even if retrieving fails). The store is not locked while details are
+// fetched.
+func (fs *FilmStore) retrieve(film Film) (*FilmRecord, error) {
+	fs.mu.Lock()
 	fr, ok := fs.Films[film.LBxdID]
 	if !ok { // new tmp record if one does not exist
 		fr = &FilmRecord{
@@ -124,27 +239,151 @@ func (fs *FilmStore) retrieve(film Film) error {
 		}
 		fs.Films[film.LBxdID] = fr
 	}
-	if fr.Details != nil && time.Since(fr.Checked) < filmExpireTime {
-		return nil
+	if fr.Details != nil && !fr.Expired() {
+		fs.mu.Unlock()
+		return fr, nil
+	}
+	record := *fr
+	fs.mu.Unlock()
+	rd, err := fetchDetails(record)
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fr.TMDBID = rd.tmdbID
+	if err != nil {
+		return fr, err
 	}
-	if fr.TMDBID == 0 {
-		var err error
-		fr.TMDBID, err = ScrapeFilmID(film.Url)
-		if err != nil {
-			return fmt.Errorf("couldn't get TMDB id, %w", err)
+	fr.setDetails(rd)
+	return fr, nil
+}
+
+// Details fetched for a film record. These are kept apart from the record
+// itself so they can be fetched in the background and applied all at once.
+type recordDetails struct {
+	tmdbID      int
+	details     *tmdb.MovieDetails
+	releaseDate time.Time
+	collection  *Collection
+	lbxdRating  float64
+	ratingTime  time.Time
+	locale      string
+}
+
+// fetch details for a copy of a film record. This involves scrapping
+// letterboxd for the TMDB id and average rating (only if the id is unknown or
+// the rating is stale), and then querying TMDB for details. The record's
+// rating is kept if scraping fails, and the TMDB id is set in the returned
+// details even if the TMDB query fails.
+func fetchDetails(fr FilmRecord) (rd recordDetails, err error) {
+	film := fr.Film
+	rd.locale = currentLocale()
+	rd.tmdbID, rd.lbxdRating, rd.ratingTime = fr.TMDBID, fr.LBxdRating, fr.RatingTime
+	if rd.tmdbID == 0 || (film.Url != "" && fr.ratingExpired()) {
+		page, err := ScrapeFilmPage(film.Url)
+		switch {
+		case err != nil && rd.tmdbID == 0:
+			return rd, fmt.Errorf("couldn't get TMDB id, %w", err)
+		case err != nil:
+			log.Printf("could not get letterboxd rating for %s, %s", film, err)
+		default:
+			rd.tmdbID = page.TMDBID
+			rd.lbxdRating, rd.ratingTime = page.AverageRating, time.Now()
 		}
 	}
-	var err error
-	fr.Details, err = TMDBFilm(fr.TMDBID)
+	rd.details, err = TMDBFilm(rd.tmdbID)
 	if err != nil {
-		return err
+		return rd, err
 	}
-	fr.ReleaseDate, err = time.Parse("2006-01-02", fr.Details.ReleaseDate)
-	if err != nil {
-		log.Printf("failed to parse release date %s as time", fr.Details.ReleaseDate)
+	if date, ok := regionalReleaseDate(rd.details, Config.TMDB.Region); ok {
+		rd.releaseDate = date
+	} else if rd.releaseDate, err = time.Parse("2006-01-02", rd.details.ReleaseDate); err != nil {
+		log.Printf("failed to parse release date %s as time", rd.details.ReleaseDate)
+	}
+	if id := rd.details.BelongsToCollection.ID; id != 0 {
+		if rd.collection, err = TMDBCollection(int(id)); err != nil {
+			log.Printf("could not get collection for %s, %s", film, err)
+		}
 	}
+	return rd, nil
+}
+
+func (fr *FilmRecord) setDetails(rd recordDetails) {
+	fr.TMDBID = rd.tmdbID
+	fr.Details = rd.details
+	fr.ReleaseDate = rd.releaseDate
+	fr.Collection = rd.collection
+	fr.LBxdRating = rd.lbxdRating
+	fr.RatingTime = rd.ratingTime
+	fr.Locale = rd.locale
 	fr.Checked = time.Now()
-	return nil
+}
+
+// Details fetched in the background for a stale film record.
+type FilmRefresh struct {
+	store  *FilmStore
+	record *FilmRecord
+	rd     recordDetails
+	err    error
+}
+
+// Channel background refreshes are sent on once they finish. Receiving them
+// turns on stale-while-revalidate (if enabled in the config), so callers that
+// can't apply refreshes (such as headless commands) don't call this and expired
+// records are retrieved before being returned instead. Records are only
+// updated when a refresh is applied, and until then stale details are served.
+func (fs *FilmStore) Refreshes() <-chan FilmRefresh {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if fs.refreshes == nil {
+		fs.refreshes = make(chan FilmRefresh)
+	}
+	return fs.refreshes
+}
+
+// Stores refreshed details in their record. If the refresh failed, the stale
+// details are kept as is.
+func (r FilmRefresh) Apply() {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.record.refreshing = false
+	if r.err != nil {
+		log.Printf("background refresh of %s failed, %s", r.record.Film, r.err)
+		return
+	}
+	r.record.setDetails(r.rd)
+	log.Printf("refreshed details for %s in the background", r.record.Film)
+}
+
+// Refresh record details in the background, sending the result on the store's
+// refreshes channel. Must be called with the store locked.
+func (fs *FilmStore) revalidate(fr *FilmRecord) {
+	if fr.refreshing {
+		return
+	}
+	fr.refreshing = true
+	record, refreshes := *fr, fs.refreshes
+	go func() {
+		rd, err := fetchDetails(record)
+		refreshes <- FilmRefresh{store: fs, record: fr, rd: rd, err: err}
+	}()
+}
+
+// Checks if record details are older than the configured expiry time, or were
+// fetched for a different language or region.
+func (fr *FilmRecord) Expired() bool {
+	return time.Since(fr.Checked) >= filmExpireTime() || fr.Locale != currentLocale()
+}
+
+// Checks if letterboxd rating should be scraped again.
+func (fr *FilmRecord) ratingExpired() bool {
+	return time.Since(fr.RatingTime) >= ratingExpireFactor*filmExpireTime()
+}
+
+// Time after which film records are considered expired (see config).
+func filmExpireTime() time.Duration {
+	if days := Config.Cache.FilmExpireDays; days > 0 {
+		return time.Duration(days) * 24 * time.Hour
+	}
+	return defaultFilmExpireTime
 }
 
 func (fd *FilmRecord) DirectorString() string {
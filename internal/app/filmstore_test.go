@@ -148,7 +148,7 @@ func TestFilmStoreClean(t *testing.T) {
 		},
 		{
 			name:   "retains expired film with refs",
-			record: map[int]*FilmRecord{1: {Film: Film{LBxdID: 1}, NRefs: 1, Checked: time.Now().Add(-filmExpireTime - time.Second)}},
+			record: map[int]*FilmRecord{1: {Film: Film{LBxdID: 1}, NRefs: 1, Checked: time.Now().Add(-filmExpireTime() - time.Second)}},
 			want:   map[int]bool{1: true},
 		},
 		{
@@ -179,6 +179,7 @@ func TestFilmStoreClean(t *testing.T) {
 }
 
 func TestFilmStoreLookup(t *testing.T) {
+	resetOffline(t)
 	testCases := []struct {
 		name     string
 		existing map[int]*FilmRecord
@@ -270,3 +271,111 @@ func TestFilmStoreLookup(t *testing.T) {
 		})
 	}
 }
+
+func TestFilmStoreLookupStale(t *testing.T) {
+	resetOffline(t)
+	testCases := []struct {
+		name    string
+		swr     bool
+		wantErr bool
+	}{
+		{
+			name:    "returns stale record when revalidating",
+			swr:     true,
+			wantErr: false,
+		},
+		{
+			name:    "blocks on refresh without revalidating",
+			swr:     false,
+			wantErr: true,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			prev := Config.Cache
+			t.Cleanup(func() { Config.Cache = prev })
+			Config.Cache.StaleWhileRevalidate = test.swr
+			checked := time.Now().Add(-filmExpireTime() - time.Hour)
+			fs := &FilmStore{Films: map[int]*FilmRecord{
+				1: {
+					Film:    Film{LBxdID: 1, Title: "Stale", Url: "https://example.com/not-letterboxd"},
+					Details: &tmdb.MovieDetails{ID: 1, Title: "Stale"},
+					NRefs:   1,
+					Checked: checked,
+				},
+			}}
+			refreshes := fs.Refreshes()
+			record, err := fs.Lookup(Film{LBxdID: 1, Url: "https://example.com/not-letterboxd"})
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if record.Details == nil || record.Details.Title != "Stale" {
+				t.Fatalf("expected stale details to be returned, got %+v", record.Details)
+			}
+			select {
+			case r := <-refreshes:
+				if r.record != record {
+					t.Fatalf("refresh is for %s, want %s", r.record.Film, record.Film)
+				}
+				r.Apply()
+			case <-time.After(5 * time.Second):
+				t.Fatalf("background refresh did not finish")
+			}
+			if record.refreshing || record.Details.Title != "Stale" || !record.Checked.Equal(checked) {
+				t.Errorf("expected failed refresh to keep stale details, got %+v", record)
+			}
+		})
+	}
+}
+
+func TestFilmExpireTime(t *testing.T) {
+	testCases := []struct {
+		name string
+		days int
+		want time.Duration
+	}{
+		{name: "uses default when unset", days: 0, want: defaultFilmExpireTime},
+		{name: "uses default when negative", days: -3, want: defaultFilmExpireTime},
+		{name: "uses configured days", days: 7, want: 7 * 24 * time.Hour},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			prev := Config.Cache
+			t.Cleanup(func() { Config.Cache = prev })
+			Config.Cache.FilmExpireDays = test.days
+			if got := filmExpireTime(); got != test.want {
+				t.Fatalf("expected %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestFilmRecordRatingExpired(t *testing.T) {
+	testCases := []struct {
+		name  string
+		age   time.Duration
+		never bool
+		want  bool
+	}{
+		{name: "never scraped", never: true, want: true},
+		{name: "scraped when details expired", age: filmExpireTime(), want: false},
+		{name: "stale", age: ratingExpireFactor * filmExpireTime(), want: true},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			fr := FilmRecord{RatingTime: time.Now().Add(-test.age)}
+			if test.never {
+				fr.RatingTime = time.Time{}
+			}
+			if got := fr.ratingExpired(); got != test.want {
+				t.Errorf("want %t, got %t", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrGroupNotFound = errors.New("group not found")
+	ErrInvalidGroup  = errors.New("invalid group")
+)
+
+// How watchlists of group members are combined.
+type GroupRule int
+
+const (
+	GroupIntersection GroupRule = iota // films on every member's watchlist
+	GroupUnion                         // films on any member's watchlist
+)
+
+func (r GroupRule) String() string {
+	if r == GroupUnion {
+		return "union"
+	}
+	return "intersection"
+}
+
+// Parses group rule from config ("union" or "intersection").
+func parseGroupRule(s string) (GroupRule, error) {
+	switch strings.ToLower(s) {
+	case "", "intersection":
+		return GroupIntersection, nil
+	case "union":
+		return GroupUnion, nil
+	}
+	return 0, fmt.Errorf("%w, unknown rule %q", ErrInvalidGroup, s)
+}
+
+// Group of Letterboxd members (including the user) who watch films together.
+// The group has its own Next Watch queue picked from the members' combined
+// watchlists.
+type Group struct {
+	Name        string           // name of group
+	Members     []string         // usernames of other members (the user is always included)
+	Rule        GroupRule        // how members' watchlists are combined
+	ExcludeSeen bool             // exclude films that any member has watched
+	Configured  bool             // group was defined in the config
+	Pool        FilmsSet         // films the queue picks from
+	Wants       map[int][]string // usernames of members with each film in pool on their watchlist
+	Seen        FilmsSet         // films watched by other members (only if ExcludeSeen)
+	SeenChecked time.Time        // last time other members' watched films were scraped
+	NextWatch   NextWatch        // group's queue of films picked from Pool
+}
+
+// Members that want to watch film (i.e., have it on their watchlist).
+func (g *Group) WantedBy(film Film) []string {
+	return g.Wants[film.LBxdID]
+}
+
+// Finds group by name. Returns nil if there is no such group.
+func (app *Application) Group(name string) *Group {
+	for _, g := range app.Groups {
+		if g.Name == name {
+			return g
+		}
+	}
+	return nil
+}
+
+// Makes group (replacing any group with the same name) and fills its queue.
+func (app *Application) MakeGroup(name string, members []string, rule GroupRule, excludeSeen bool) error {
+	g := &Group{Name: name, Rule: rule, ExcludeSeen: excludeSeen}
+	var err error
+	if g.Members, err = groupMembers(members, app.Username); err != nil {
+		return err
+	}
+	if len(g.Members) == 0 {
+		return fmt.Errorf("%w, group %s has no other members", ErrInvalidGroup, name)
+	}
+	if err := app.refreshGroup(g); err != nil {
+		return err
+	}
+	if g.NextWatch, err = app.makeNextWatchFrom(g.Pool); err != nil {
+		app.FilmStore.DeregisterSet(g.Pool)
+		return fmt.Errorf("could not make queue for %s (%d films), %w", name, len(g.Pool), err)
+	}
+	app.RemoveGroup(name)
+	app.Groups = append(app.Groups, g)
+	return nil
+}
+
+// Cleans members' usernames, leaving out duplicates and the user themselves.
+func groupMembers(members []string, self string) ([]string, error) {
+	cleaned := make([]string, 0, len(members))
+	for _, m := range members {
+		username, err := cleanUsername(m)
+		if err != nil {
+			return nil, fmt.Errorf("%w, %w", ErrInvalidGroup, err)
+		}
+		if username != strings.ToLower(self) && !slices.Contains(cleaned, username) {
+			cleaned = append(cleaned, username)
+		}
+	}
+	return cleaned, nil
+}
+
+// Removes group and its queue.
+func (app *Application) RemoveGroup(name string) {
+	app.Groups = slices.DeleteFunc(app.Groups, func(g *Group) bool {
+		if g.Name != name {
+			return false
+		}
+		app.FilmStore.DeregisterSet(g.Pool)
+		return true
+	})
+}
+
+// Name of group made for watching with a single member.
+func sharedGroupName(username string) string {
+	return "With " + username
+}
+
+// Makes queue shared with member, picking films on both users' watchlists.
+func (app *Application) ShareQueue(username string) error {
+	username, err := cleanUsername(username)
+	if err != nil {
+		return err
+	}
+	return app.MakeGroup(sharedGroupName(username), []string{username}, GroupIntersection, false)
+}
+
+// Removes queue shared with member.
+func (app *Application) StopSharingQueue(username string) {
+	username, _ = cleanUsername(username)
+	app.RemoveGroup(sharedGroupName(username))
+}
+
+// Checks if user has a queue shared with member.
+func (app *Application) SharingQueue(username string) bool {
+	username, _ = cleanUsername(username)
+	return app.Group(sharedGroupName(username)) != nil
+}
+
+// Rescrapes members' watchlists (and watched films if needed) and recombines
+// them into the group's pool.
+func (app *Application) refreshGroup(g *Group) error {
+	watchlists := map[string]FilmsSet{app.Username: app.Watchlist}
+	for _, m := range g.Members {
+		wl, err := retrieveWatchlist(m)
+		if err != nil {
+			return fmt.Errorf("could not get watchlist of %s, %w", m, err)
+		}
+		watchlists[m] = wl
+	}
+	if g.ExcludeSeen && time.Since(g.SeenChecked) > watchedFullSyncTime {
+		seen := make(FilmsSet)
+		for _, m := range g.Members {
+			watched, _, err := retrieveWatchedFilms(m)
+			if err != nil {
+				return fmt.Errorf("could not get watched films of %s, %w", m, err)
+			}
+			for id, f := range watched {
+				seen[id] = f
+			}
+		}
+		g.Seen, g.SeenChecked = seen, time.Now()
+	} else if !g.ExcludeSeen {
+		g.Seen = nil
+	}
+	pool, wants := combineWatchlists(g.Rule, watchlists, app.Watchlist)
+	for id := range g.Seen {
+		delete(pool, id)
+		delete(wants, id)
+	}
+	app.FilmStore.RegisterSet(pool)
+	if g.Pool != nil {
+		app.FilmStore.DeregisterSet(g.Pool)
+	}
+	g.Pool, g.Wants = pool, wants
+	return nil
+}
+
+// Combines watchlists (indexed by username) by rule. Films from preferred
+// are used where possible so that the pool shares films with it. Also returns
+// which members want each film (usernames are sorted).
+func combineWatchlists(rule GroupRule, watchlists map[string]FilmsSet, preferred FilmsSet) (FilmsSet, map[int][]string) {
+	wants := make(map[int][]string)
+	films := make(FilmsSet)
+	for username, wl := range watchlists {
+		for id, f := range wl {
+			wants[id] = append(wants[id], username)
+			if _, ok := films[id]; !ok {
+				films[id] = f
+			}
+		}
+	}
+	pool := make(FilmsSet)
+	for id, members := range wants {
+		if rule == GroupIntersection && len(members) != len(watchlists) {
+			delete(wants, id)
+			continue
+		}
+		slices.Sort(members)
+		if f, ok := preferred[id]; ok {
+			pool[id] = f
+		} else {
+			pool[id] = films[id]
+		}
+	}
+	return pool, wants
+}
+
+// Updates all groups, making groups defined in the config that don't exist
+// yet (or have changed) and removing configured groups that are no longer in
+// the config.
+func (app *Application) updateGroups() error {
+	var lastErr error
+	configured := make(map[string]bool, len(Config.Groups))
+	made := make(map[string]bool) // groups that were just made, so are up to date
+	for _, gc := range Config.Groups {
+		configured[gc.Name] = true
+		rule, err := parseGroupRule(gc.Rule)
+		if err != nil {
+			lastErr = fmt.Errorf("group %s in config, %w", gc.Name, err)
+			continue
+		}
+		if g := app.Group(gc.Name); g == nil || !g.matches(gc, rule, app.Username) {
+			if err := app.MakeGroup(gc.Name, gc.Members, rule, gc.ExcludeSeen); err != nil {
+				if isNetworkError(err) {
+					return err
+				}
+				lastErr = err
+				continue
+			}
+			app.Group(gc.Name).Configured = true
+			made[gc.Name] = true
+		}
+	}
+	app.Groups = slices.DeleteFunc(app.Groups, func(g *Group) bool {
+		if g.Configured && !configured[g.Name] {
+			app.FilmStore.DeregisterSet(g.Pool)
+			return true
+		}
+		return false
+	})
+	unfilled := make(map[string]bool) // groups whose queues can no longer be filled
+	for _, g := range app.Groups {
+		if made[g.Name] {
+			continue
+		}
+		log.Printf("updating group %s", g.Name)
+		if err := app.refreshGroup(g); err != nil {
+			if isNetworkError(err) {
+				return err
+			}
+			lastErr = err
+			continue
+		}
+		g.rehydrate(app)
+		if err := g.NextWatch.UpdateWatched(); err != nil {
+			lastErr = fmt.Errorf("could not update queue of group %s, dropping group until it can be remade, %w", g.Name, err)
+			unfilled[g.Name] = true
+		}
+	}
+	for name := range unfilled { // configured groups are remade once there are enough films
+		app.RemoveGroup(name)
+	}
+	return lastErr
+}
+
+// Checks if group was made from the given config by the user self.
+func (g *Group) matches(gc groupConfig, rule GroupRule, self string) bool {
+	if g.Rule != rule || g.ExcludeSeen != gc.ExcludeSeen {
+		return false
+	}
+	members, err := groupMembers(gc.Members, self)
+	if err != nil || len(members) != len(g.Members) {
+		return false
+	}
+	for _, m := range members {
+		if !slices.Contains(g.Members, m) {
+			return false
+		}
+	}
+	return true
+}
+
+// Post JSON unmarshal setup
+func (g *Group) rehydrate(app *Application) {
+	if g.NextWatch.lastUpdated == nil {
+		g.NextWatch.makeLastUpdate()
+	}
+	g.NextWatch.watchlist = g.Pool // films seen by other members are left out of the pool
+	g.NextWatch.watchedFilms = app.WatchedFilms
+	g.NextWatch.store = &app.FilmStore
+}
@@ -0,0 +1,173 @@
+package app
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCombineWatchlists(t *testing.T) {
+	a, b, c := &Film{LBxdID: 1}, &Film{LBxdID: 2}, &Film{LBxdID: 3}
+	mine := FilmsSet{1: a, 2: b}
+	watchlists := map[string]FilmsSet{
+		"me":      mine,
+		"partner": {1: {LBxdID: 1}, 3: c},
+		"friend":  {1: {LBxdID: 1}, 2: {LBxdID: 2}},
+	}
+	testCases := []struct {
+		name      string
+		rule      GroupRule
+		wantPool  FilmsSet
+		wantWants map[int][]string
+	}{
+		{
+			name:      "intersection",
+			rule:      GroupIntersection,
+			wantPool:  FilmsSet{1: a},
+			wantWants: map[int][]string{1: {"friend", "me", "partner"}},
+		},
+		{
+			name:     "union",
+			rule:     GroupUnion,
+			wantPool: FilmsSet{1: a, 2: b, 3: c},
+			wantWants: map[int][]string{
+				1: {"friend", "me", "partner"},
+				2: {"friend", "me"},
+				3: {"partner"},
+			},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			pool, wants := combineWatchlists(test.rule, watchlists, mine)
+			if len(pool) != len(test.wantPool) {
+				t.Fatalf("want pool %v, got %v", test.wantPool, pool)
+			}
+			for id := range test.wantPool {
+				if _, ok := pool[id]; !ok {
+					t.Errorf("film %d missing from pool", id)
+				}
+			}
+			if pool[1] != a {
+				t.Errorf("expected pool to use film from preferred set")
+			}
+			if !reflect.DeepEqual(test.wantWants, wants) {
+				t.Errorf("want wants %v, got %v", test.wantWants, wants)
+			}
+		})
+	}
+}
+
+func TestParseGroupRule(t *testing.T) {
+	testCases := []struct {
+		rule    string
+		want    GroupRule
+		wantErr error
+	}{
+		{rule: "", want: GroupIntersection},
+		{rule: "Intersection", want: GroupIntersection},
+		{rule: "union", want: GroupUnion},
+		{rule: "everyone", wantErr: ErrInvalidGroup},
+	}
+	for _, test := range testCases {
+		t.Run(test.rule, func(t *testing.T) {
+			got, err := parseGroupRule(test.rule)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("expected error %v, got %v", test.wantErr, err)
+			}
+			if got != test.want {
+				t.Errorf("want %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestGroupMatches(t *testing.T) {
+	g := &Group{Members: []string{"partner", "friend"}, Rule: GroupUnion, ExcludeSeen: true}
+	testCases := []struct {
+		name string
+		gc   groupConfig
+		rule GroupRule
+		want bool
+	}{
+		{name: "same", gc: groupConfig{Members: []string{"Friend", "@partner"}, ExcludeSeen: true}, rule: GroupUnion, want: true},
+		{name: "different rule", gc: groupConfig{Members: []string{"friend", "partner"}, ExcludeSeen: true}, rule: GroupIntersection},
+		{name: "different members", gc: groupConfig{Members: []string{"friend", "other"}, ExcludeSeen: true}, rule: GroupUnion},
+		{name: "seen not excluded", gc: groupConfig{Members: []string{"friend", "partner"}}, rule: GroupUnion},
+		{name: "includes user", gc: groupConfig{Members: []string{"Me", "friend", "partner"}, ExcludeSeen: true}, rule: GroupUnion, want: true},
+		{name: "duplicate member", gc: groupConfig{Members: []string{"friend", "@Friend", "partner"}, ExcludeSeen: true}, rule: GroupUnion, want: true},
+		{name: "duplicate instead of member", gc: groupConfig{Members: []string{"friend", "friend"}, ExcludeSeen: true}, rule: GroupUnion},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			if got := g.matches(test.gc, test.rule, "me"); got != test.want {
+				t.Errorf("want %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestRemoveGroup(t *testing.T) {
+	film := &Film{LBxdID: 1}
+	app := &Application{FilmStore: FilmStore{Films: map[int]*FilmRecord{}}}
+	app.FilmStore.register(*film)
+	app.Groups = []*Group{
+		{Name: "keep"},
+		{Name: "With partner", Pool: FilmsSet{1: film}},
+	}
+	if !app.SharingQueue("@Partner") {
+		t.Fatalf("expected queue to be shared with partner")
+	}
+	app.StopSharingQueue("partner")
+	if app.SharingQueue("partner") || app.Group("keep") == nil || len(app.Groups) != 1 {
+		t.Errorf("expected only shared group to be removed, got %+v", app.Groups)
+	}
+	if refs := app.FilmStore.Films[1].NRefs; refs != 0 {
+		t.Errorf("expected pool to be deregistered, %d refs left", refs)
+	}
+}
+
+func TestMakeGroupInvalid(t *testing.T) {
+	app := &Application{Username: "me"}
+	if err := app.MakeGroup("alone", []string{"@me"}, GroupUnion, false); !errors.Is(err, ErrInvalidGroup) {
+		t.Errorf("expected %v, got %v", ErrInvalidGroup, err)
+	}
+}
+
+func TestMakeNextWatchFromPool(t *testing.T) {
+	resetOffline(t)
+	testCases := []struct {
+		name    string
+		pool    int
+		wantErr error
+	}{
+		{name: "enough films in pool", pool: NumberOfStacks*StackSize + 1},
+		{name: "too few films in pool", pool: NumberOfStacks * StackSize, wantErr: ErrNotEnoughFilms},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			watchlist := make(FilmsSet)
+			for id := 1; id <= 100; id++ {
+				watchlist[id] = &Film{LBxdID: id}
+			}
+			pool := make(FilmsSet)
+			for id := 1; id <= test.pool; id++ {
+				pool[id] = watchlist[id]
+			}
+			app := Application{Watchlist: watchlist, WatchedFilms: make(FilmsSet)}
+			seedFilmStore(t, &app.FilmStore, watchlist)
+			nw, err := app.makeNextWatchFrom(pool)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("expected error %v, got %v", test.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			for i, j := range nw.Positions() {
+				if !pool.InSet(nw.Stacks[i][j]) {
+					t.Errorf("film %d in queue is not in pool", nw.Stacks[i][j].LBxdID)
+				}
+			}
+		})
+	}
+}
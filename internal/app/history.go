@@ -0,0 +1,110 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+const maxHistory = 100 // number of list changes kept in the history
+
+// Changes to a tracked list found when it was refreshed.
+type ListDiff struct {
+	List      string    // name of list
+	Url       string    // letterboxd list url
+	Time      time.Time // when the change was found
+	Added     []*Film   // films added to the list
+	Removed   []*Film   // films removed from the list
+	Reordered bool      // films that were already in the list changed order
+}
+
+// Reports whether the list was unchanged.
+func (ld ListDiff) Empty() bool {
+	return len(ld.Added) == 0 && len(ld.Removed) == 0 && !ld.Reordered
+}
+
+// Summarizes changes (e.g., "3 new films added to Sight & Sound, 1 removed").
+func (ld ListDiff) String() string {
+	parts := make([]string, 0, 3)
+	if n := len(ld.Added); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d new %s added to %s", n, plural(n, "film", "films"), ld.List))
+	}
+	if n := len(ld.Removed); n > 0 {
+		if len(parts) == 0 {
+			parts = append(parts, fmt.Sprintf("%d %s removed from %s", n, plural(n, "film", "films"), ld.List))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d removed", n))
+		}
+	}
+	if ld.Reordered {
+		if len(parts) == 0 {
+			parts = append(parts, fmt.Sprintf("%s was reordered", ld.List))
+		} else {
+			parts = append(parts, "reordered")
+		}
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("%s is unchanged", ld.List)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+// Computes films added to and removed from a list, and whether the films that
+// remain changed order.
+func diffFilmLists(prev, cur []*Film) ListDiff {
+	var diff ListDiff
+	prevIdx := make(map[int]int, len(prev))
+	for i, f := range prev {
+		prevIdx[f.LBxdID] = i
+	}
+	curIds := make(map[int]bool, len(cur))
+	last := -1 // index in prev of last film that remains in list
+	for _, f := range cur {
+		curIds[f.LBxdID] = true
+		i, ok := prevIdx[f.LBxdID]
+		if !ok {
+			diff.Added = append(diff.Added, f)
+			continue
+		}
+		if i < last {
+			diff.Reordered = true
+		}
+		last = i
+	}
+	for _, f := range prev {
+		if !curIds[f.LBxdID] {
+			diff.Removed = append(diff.Removed, f)
+		}
+	}
+	return diff
+}
+
+// Adds list changes to the history (and to the changes not yet reported).
+// Lists without changes are not recorded.
+func (app *Application) recordListDiff(diff ListDiff) {
+	if diff.Empty() {
+		return
+	}
+	log.Printf("list changed: %s", diff)
+	app.History = append(app.History, diff)
+	if len(app.History) > maxHistory {
+		app.History = app.History[len(app.History)-maxHistory:]
+	}
+	app.unreported = append(app.unreported, diff)
+}
+
+// Returns list changes found since this was last called (e.g., to show after
+// updating).
+func (app *Application) TakeListChanges() []ListDiff {
+	changes := app.unreported
+	app.unreported = nil
+	return changes
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffFilmLists(t *testing.T) {
+	a, b, c, d := &Film{LBxdID: 1}, &Film{LBxdID: 2}, &Film{LBxdID: 3}, &Film{LBxdID: 4}
+	testCases := []struct {
+		name string
+		prev []*Film
+		cur  []*Film
+		want ListDiff
+	}{
+		{
+			name: "unchanged",
+			prev: []*Film{a, b, c},
+			cur:  []*Film{a, b, c},
+			want: ListDiff{},
+		},
+		{
+			name: "added and removed",
+			prev: []*Film{a, b, c},
+			cur:  []*Film{a, c, d},
+			want: ListDiff{Added: []*Film{d}, Removed: []*Film{b}},
+		},
+		{
+			name: "insertion is not a reorder",
+			prev: []*Film{a, c},
+			cur:  []*Film{a, b, c},
+			want: ListDiff{Added: []*Film{b}},
+		},
+		{
+			name: "reordered",
+			prev: []*Film{a, b, c},
+			cur:  []*Film{b, a, c},
+			want: ListDiff{Reordered: true},
+		},
+		{
+			name: "new list",
+			prev: nil,
+			cur:  []*Film{a, b},
+			want: ListDiff{Added: []*Film{a, b}},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			if got := diffFilmLists(test.prev, test.cur); !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestListDiffString(t *testing.T) {
+	f := &Film{LBxdID: 1}
+	testCases := []struct {
+		name string
+		diff ListDiff
+		want string
+	}{
+		{
+			name: "added",
+			diff: ListDiff{List: "Sight & Sound", Added: []*Film{f, f, f}},
+			want: "3 new films added to Sight & Sound",
+		},
+		{
+			name: "added and removed",
+			diff: ListDiff{List: "Sight & Sound", Added: []*Film{f}, Removed: []*Film{f}, Reordered: true},
+			want: "1 new film added to Sight & Sound, 1 removed, reordered",
+		},
+		{
+			name: "removed",
+			diff: ListDiff{List: "Sight & Sound", Removed: []*Film{f, f}},
+			want: "2 films removed from Sight & Sound",
+		},
+		{
+			name: "reordered",
+			diff: ListDiff{List: "Sight & Sound", Reordered: true},
+			want: "Sight & Sound was reordered",
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.diff.String(); got != test.want {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestRecordListDiff(t *testing.T) {
+	app := &Application{}
+	app.recordListDiff(ListDiff{List: "unchanged"})
+	for range maxHistory + 5 {
+		app.recordListDiff(ListDiff{List: "changed", Reordered: true})
+	}
+	if len(app.History) != maxHistory {
+		t.Errorf("expected history capped at %d, got %d", maxHistory, len(app.History))
+	}
+	if changes := app.TakeListChanges(); len(changes) != maxHistory+5 {
+		t.Errorf("expected %d unreported changes, got %d", maxHistory+5, len(changes))
+	}
+	if changes := app.TakeListChanges(); len(changes) != 0 {
+		t.Errorf("expected changes to be cleared, got %d", len(changes))
+	}
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+const defaultImageFilename = "{title}_{year}"
+
+var (
+	ErrInvalidImageKind = errors.New("invalid image kind")
+	ErrInvalidImageSize = errors.New("invalid image size")
+)
+
+// Kind of film image that can be downloaded from TMDB.
+type ImageKind string
+
+const (
+	ImagePoster   ImageKind = "poster"
+	ImageBackdrop ImageKind = "backdrop"
+	ImageLogo     ImageKind = "logo"
+)
+
+// Sizes TMDB serves each kind of image in.
+var imageSizes = map[ImageKind][]string{
+	ImagePoster:   {"w92", "w154", "w185", "w342", "w500", "w780", "original"},
+	ImageBackdrop: {"w300", "w780", "w1280", "original"},
+	ImageLogo:     {"w45", "w92", "w154", "w185", "w300", "w500", "original"},
+}
+
+// Film image on TMDB.
+type FilmImage struct {
+	Kind     ImageKind
+	Path     string // tmdb file path (e.g., "/abc.jpg")
+	Language string // ISO 639-1 code of text in image (empty if none)
+	Width    int    // width of original in pixels
+	Height   int    // height of original in pixels
+	Rating   float32
+	Votes    int64
+}
+
+// Kind of image downloaded by the poster action (see config).
+func ConfigImageKind() (ImageKind, error) {
+	kind := ImageKind(strings.ToLower(cmp.Or(Config.Posters.Kind, string(ImagePoster))))
+	if _, ok := imageSizes[kind]; !ok {
+		return "", fmt.Errorf("%w, %s (must be poster, backdrop, or logo)", ErrInvalidImageKind, Config.Posters.Kind)
+	}
+	return kind, nil
+}
+
+// Size images are downloaded in (see config), checked against the sizes TMDB
+// has for the kind of image.
+func configImageSize(kind ImageKind) (string, error) {
+	size := cmp.Or(Config.Posters.Size, "original")
+	if !slices.Contains(imageSizes[kind], size) {
+		return "", fmt.Errorf("%w, %s for %s (must be one of %s)", ErrInvalidImageSize, size, kind, strings.Join(imageSizes[kind], ", "))
+	}
+	return size, nil
+}
+
+// Language images are picked in, from the poster config or else the TMDB
+// language. Empty if none is configured.
+func imageLanguage() string {
+	lang, _, _ := strings.Cut(cmp.Or(Config.Posters.Language, Config.TMDB.Language), "-")
+	return strings.ToLower(lang)
+}
+
+// Queries TMDB for the film's images of the given kind. Images in the
+// configured language come first (if one is set; otherwise all languages are
+// included), followed by the highest rated.
+func FilmImages(tmdbID int, kind ImageKind) ([]FilmImage, error) {
+	if TMDBClient == nil {
+		return nil, ErrNoAPI
+	}
+	if err := checkOnline(); err != nil {
+		return nil, err
+	}
+	opts := map[string]string{}
+	if lang := imageLanguage(); lang != "" {
+		opts["include_image_language"] = lang + ",null"
+	}
+	res, err := TMDBClient.GetMovieImages(tmdbID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%w, images for id %d, %w", ErrFailedTMDBLookup, tmdbID, err)
+	}
+	return filmImages(res, kind, imageLanguage()), nil
+}
+
+func filmImages(res *tmdb.MovieImages, kind ImageKind, lang string) []FilmImage {
+	var results []tmdb.MovieImage
+	switch kind {
+	case ImagePoster:
+		results = res.Posters
+	case ImageBackdrop:
+		results = res.Backdrops
+	case ImageLogo:
+		results = res.Logos
+	}
+	images := make([]FilmImage, len(results))
+	for i, r := range results {
+		images[i] = FilmImage{
+			Kind:     kind,
+			Path:     r.FilePath,
+			Language: r.Iso639_1,
+			Width:    r.Width,
+			Height:   r.Height,
+			Rating:   r.VoteAverage,
+			Votes:    r.VoteCount,
+		}
+	}
+	slices.SortStableFunc(images, func(a, b FilmImage) int {
+		return cmp.Or(
+			compareBool(b.Language == lang && lang != "", a.Language == lang && lang != ""),
+			cmp.Compare(b.Rating, a.Rating),
+			cmp.Compare(b.Votes, a.Votes),
+		)
+	})
+	return images
+}
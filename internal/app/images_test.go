@@ -0,0 +1,96 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+func testImage(path, lang string, rating float32, votes int64) tmdb.MovieImage {
+	img := tmdb.MovieImage{Iso639_1: lang}
+	img.FilePath, img.VoteAverage, img.VoteCount = path, rating, votes
+	return img
+}
+
+func TestFilmImages(t *testing.T) {
+	res := &tmdb.MovieImages{
+		Posters: []tmdb.MovieImage{
+			testImage("/en.jpg", "en", 5.2, 10),
+			testImage("/fr.jpg", "fr", 5.0, 2),
+			testImage("/fr-popular.jpg", "fr", 5.0, 8),
+			testImage("/textless.jpg", "", 5.4, 3),
+		},
+		Logos: []tmdb.MovieImage{testImage("/logo.png", "en", 0, 0)},
+	}
+	testCases := []struct {
+		name string
+		kind ImageKind
+		lang string
+		want []string
+	}{
+		{name: "language first", kind: ImagePoster, lang: "fr", want: []string{"/fr-popular.jpg", "/fr.jpg", "/textless.jpg", "/en.jpg"}},
+		{name: "no language", kind: ImagePoster, want: []string{"/textless.jpg", "/en.jpg", "/fr-popular.jpg", "/fr.jpg"}},
+		{name: "logos", kind: ImageLogo, lang: "fr", want: []string{"/logo.png"}},
+		{name: "no backdrops", kind: ImageBackdrop, want: []string{}},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			images := filmImages(res, test.kind, test.lang)
+			if len(images) != len(test.want) {
+				t.Fatalf("want %d images, got %d", len(test.want), len(images))
+			}
+			for i, img := range images {
+				if img.Path != test.want[i] || img.Kind != test.kind {
+					t.Errorf("image %d: want %s %s, got %s %s", i, test.kind, test.want[i], img.Kind, img.Path)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigImageSize(t *testing.T) {
+	prev := Config.Posters
+	t.Cleanup(func() { Config.Posters = prev })
+	testCases := []struct {
+		name    string
+		kind    ImageKind
+		size    string
+		want    string
+		wantErr error
+	}{
+		{name: "default", kind: ImagePoster, want: "original"},
+		{name: "poster size", kind: ImagePoster, size: "w780", want: "w780"},
+		{name: "backdrop size", kind: ImageBackdrop, size: "w1280", want: "w1280"},
+		{name: "wrong size for kind", kind: ImageBackdrop, size: "w92", wantErr: ErrInvalidImageSize},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			Config.Posters.Size = test.size
+			got, err := configImageSize(test.kind)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("expected error %v, got %v", test.wantErr, err)
+			}
+			if got != test.want {
+				t.Errorf("want %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestImageLanguage(t *testing.T) {
+	prevPosters, prevTMDB := Config.Posters, Config.TMDB
+	t.Cleanup(func() { Config.Posters, Config.TMDB = prevPosters, prevTMDB })
+	Config.TMDB.Language = "fr-FR"
+	if got := imageLanguage(); got != "fr" {
+		t.Errorf("want fr from tmdb language, got %s", got)
+	}
+	Config.Posters.Language = "EN"
+	if got := imageLanguage(); got != "en" {
+		t.Errorf("want en from poster language, got %s", got)
+	}
+	Config.Posters.Kind = "banner"
+	if _, err := ConfigImageKind(); !errors.Is(err, ErrInvalidImageKind) {
+		t.Errorf("expected error %v, got %v", ErrInvalidImageKind, err)
+	}
+}
@@ -0,0 +1,152 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+const maxBrowseFilms = 500 // films scraped from browse pages (e.g., genres), which can be huge
+
+// Kind of Letterboxd page tracked as a list.
+type ListKind int
+
+const (
+	KindList        ListKind = iota // regular user list (/<user>/list/<name>/)
+	KindFilmography                 // person's films (e.g., /director/<name>/)
+	KindCollection                  // film collection (/films/in/<collection>/)
+	KindBrowse                      // film browse page (e.g., /films/genre/<genre>/)
+	KindWatchlist                   // member's watchlist (/<user>/watchlist/)
+	KindLikes                       // member's liked films (/<user>/likes/films/)
+	KindLocal                       // list kept in nw only (not on letterboxd)
+)
+
+// Path prefixes of Letterboxd filmography pages.
+var filmographyRoles = []string{
+	"actor", "director", "producer", "writer", "editor", "cinematography",
+	"composer", "casting", "additional-directing", "executive-producer",
+	"original-writer", "story", "songs", "production-design", "art-direction",
+	"set-decoration", "visual-effects", "sound", "costume-design", "makeup",
+	"hairstyling", "choreography", "stunts", "lighting", "camera-operator",
+	"studio",
+}
+
+func (k ListKind) String() string {
+	switch k {
+	case KindFilmography:
+		return "filmography"
+	case KindCollection:
+		return "collection"
+	case KindBrowse:
+		return "browse"
+	case KindWatchlist:
+		return "watchlist"
+	case KindLikes:
+		return "likes"
+	case KindLocal:
+		return "local"
+	default:
+		return "list"
+	}
+}
+
+// Reports whether films in lists of this kind are suggested in order by
+// default. Filmographies and collections are ordered by release date.
+func (k ListKind) defaultOrdered() bool {
+	return k == KindFilmography || k == KindCollection
+}
+
+// Determines what kind of page url is. Returns ErrInvalidUrl if the page
+// cannot be tracked as a list.
+func listKind(u *url.URL) (ListKind, error) {
+	if u.Hostname() != "letterboxd.com" {
+		return 0, fmt.Errorf("%w, %s is not a letterboxd.com url", ErrInvalidUrl, u)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch {
+	case len(parts) >= 3 && parts[1] == "list":
+		return KindList, nil
+	case len(parts) >= 2 && slices.Contains(filmographyRoles, parts[0]):
+		return KindFilmography, nil
+	case len(parts) >= 3 && parts[0] == "films" && parts[1] == "in":
+		return KindCollection, nil
+	case len(parts) >= 2 && parts[0] == "films" && parts[1] != "ajax":
+		return KindBrowse, nil
+	case len(parts) >= 2 && parts[1] == "watchlist":
+		return KindWatchlist, nil
+	case len(parts) >= 3 && parts[1] == "likes" && parts[2] == "films":
+		return KindLikes, nil
+	}
+	return 0, fmt.Errorf("%w, %s is not a letterboxd list, filmography, collection, watchlist, likes, or browse page", ErrInvalidUrl, u)
+}
+
+// Url to scrape the films of a page from. Filmographies and collections are
+// sorted by release date (unless already sorted), and browse pages are loaded
+// from the url Letterboxd uses to fill in their poster grids.
+func scrapeUrl(u *url.URL, kind ListKind) string {
+	su := *u
+	path := strings.Trim(u.Path, "/")
+	switch kind {
+	case KindFilmography, KindCollection:
+		if !strings.Contains(path, "/by/") {
+			path += "/by/release-earliest"
+		}
+	case KindBrowse:
+		path = "films/ajax/" + strings.TrimPrefix(path, "films/")
+	}
+	su.Path = "/" + path + "/"
+	return su.String()
+}
+
+// Name for a page without a list title (e.g., "Director: Christopher Nolan").
+func pageName(u *url.URL, kind ListKind) string {
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch kind {
+	case KindFilmography:
+		return fmt.Sprintf("%s: %s", titleCase(parts[0]), titleCase(parts[1]))
+	case KindCollection:
+		return titleCase(parts[2])
+	case KindBrowse:
+		return "Films: " + titleCase(strings.Join(parts[1:], " "))
+	case KindWatchlist:
+		return parts[0] + "'s Watchlist"
+	case KindLikes:
+		return parts[0] + "'s Liked Films"
+	}
+	return ""
+}
+
+// Watchlist and liked films of a member, which can be tracked as lists. Films
+// are not scraped (NumFilms is -1).
+func MemberPages(username string) []*FilmList {
+	pages := []struct {
+		kind ListKind
+		path string
+	}{
+		{KindWatchlist, "watchlist"},
+		{KindLikes, "likes/films"},
+	}
+	lists := make([]*FilmList, 0, len(pages))
+	for _, page := range pages {
+		u, err := url.Parse(fmt.Sprintf("%s/%s/%s/", LetterboxdUrl, username, page.path))
+		if err != nil {
+			log.Printf("could not make url for %s's %s, %s", username, page.kind, err)
+			continue
+		}
+		lists = append(lists, &FilmList{
+			Name: pageName(u, page.kind), Url: u.String(), Kind: page.kind, Owner: username, NumFilms: -1,
+		})
+	}
+	return lists
+}
+
+// Converts url slug to title case (e.g., "christopher-nolan" to "Christopher Nolan").
+func titleCase(slug string) string {
+	words := strings.FieldsFunc(slug, func(r rune) bool { return r == '-' || r == ' ' })
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
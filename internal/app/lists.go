@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
+	"net/url"
+	"slices"
 	"strings"
+	"time"
 )
 
 var (
@@ -17,14 +20,23 @@ var (
 
 // Film list that user might track
 type FilmList struct {
-	Name     string   // name of list on letterboxd
-	Desc     string   // description of list
-	Url      string   // letterboxd list url
-	NumFilms int      // number of films in list
-	Ordered  bool     // is the list ordered
-	NextFilm *Film    // the next film to be suggested
-	Films    []*Film  // films in list (can be nil)
-	watched  FilmsSet // for checking whether film is watched
+	Name     string         // name of list on letterboxd
+	Desc     string         // description of list
+	Url      string         // letterboxd list url
+	NumFilms int            // number of films in list
+	Ordered  bool           // is the list ordered
+	NextFilm *Film          // the next film to be suggested
+	Films    []*Film        // films in list (can be nil)
+	Kind     ListKind       // kind of page the list was scraped from
+	Owner    string         // username of list owner
+	Tags     []string       // tags on letterboxd
+	Updated  time.Time      // when list was last published or updated
+	Likes    int            // number of likes on letterboxd
+	Notes    map[int]string // notes on list entries indexed by letterboxd id
+	watched  FilmsSet       // for checking whether film is watched
+	store    *FilmStore     // for checking saved film details
+
+	ratings map[int]*FilmRating // ratings shown on page (only on a user's films pages)
 }
 
 // Changed Ordered status; clears NextFilm
@@ -38,10 +50,12 @@ func (fl *FilmList) ToggleOrdered() {
 // Returns NextFilm if it has not been watched and it has been set. Otherwise
 // recalculate the next film to watch. If ordered, it is simply the first
 // unwatched film; otherwise, list is shuffled and first unwatched film is
-// selected.
+// selected, skipping sequels whose predecessors have not been watched if
+// sequels_in_order is set in the config.
 //
 // If the list is empty, the function returns ErrListEmpty. If all the films
-// are watched, then ErrNoValidFilm is returned.
+// are watched, then ErrNoValidFilm is returned. If the only unwatched films
+// are sequels being held back, then ErrWaitingOnPredecessors is returned.
 func (fl *FilmList) NextWatch() (Film, error) {
 	if len(fl.Films) == 0 {
 		return Film{}, ErrListEmpty
@@ -59,13 +73,29 @@ func (fl *FilmList) NextWatch() (Film, error) {
 	} else {
 		tmpList = fl.Films
 	}
+	var matcher *FilmMatcher
+	filmMatcher := func() *FilmMatcher {
+		if matcher == nil {
+			matcher = newFilmMatcher(fl.store, fl.watched)
+		}
+		return matcher
+	}
+	heldBack := false
 	for _, f := range tmpList {
-		if !fl.watched.InSet(f) {
-			fl.NextFilm = f
-			return *f, nil
+		if fl.watched.InSet(f) {
+			continue
+		}
+		if !fl.Ordered && fl.store != nil && holdBackSequel(fl.store, *f, filmMatcher, fl.watched) {
+			heldBack = true
+			continue
 		}
+		fl.NextFilm = f
+		return *f, nil
 	}
 	fl.NextFilm = nil
+	if heldBack {
+		return Film{}, fmt.Errorf("%w, only sequels left unwatched in %s", ErrWaitingOnPredecessors, fl.Name)
+	}
 	return Film{}, fmt.Errorf("%w, no unwatched films in %s", ErrNoValidFilm, fl.Name)
 }
 
@@ -73,13 +103,14 @@ func (fl *FilmList) NextWatch() (Film, error) {
 
 // Saves list in map of list tracked by the user.
 func (app *Application) AddList(filmList *FilmList) error {
-	if filmList.Films == nil && filmList.NumFilms > 0 {
+	if filmList.Films == nil && filmList.NumFilms != 0 { // negative if number of films is unknown
 		if err := app.AddListFromUrl(filmList.Url); err != nil {
 			return err
 		}
 		return nil
 	}
 	filmList.watched = app.WatchedFilms
+	filmList.store = &app.FilmStore
 	app.FilmStore.RegisterList(filmList)
 	app.TrackedLists[filmList.Url] = filmList
 	return nil
@@ -96,17 +127,73 @@ func (app *Application) RemoveList(filmList *FilmList) error {
 	return nil
 }
 
-// Rescrapes the list films and data from Letterboxd.
-func (app *Application) RefreshList(filmList *FilmList) error {
+// Rescrapes the list films and data from Letterboxd. Settings made by the
+// user (i.e., ordering and the next film) are kept, and the changes since the
+// list was last scraped are recorded in the history.
+func (app *Application) RefreshList(filmList *FilmList) (ListDiff, error) {
+	if filmList.Kind == KindLocal { // nothing to scrape
+		return ListDiff{}, nil
+	}
 	log.Printf("refreshing list %s", filmList.Name)
 	if err := app.RemoveList(filmList); err != nil {
-		return err
+		return ListDiff{}, err
 	}
-	if err := app.AddListFromUrl(filmList.Url); err != nil {
+	list, err := scrapeTrackedList(filmList.Url)
+	if err != nil {
 		_ = app.AddList(filmList) // re-add old list if scraping failed, should not fail with error
-		return err
+		return ListDiff{}, err
 	}
-	return nil
+	diff := diffFilmLists(filmList.Films, list.Films)
+	diff.List, diff.Url, diff.Time = list.Name, list.Url, time.Now()
+	list.Ordered = filmList.Ordered
+	if filmList.NextFilm != nil {
+		list.NextFilm = findFilm(list.Films, filmList.NextFilm.LBxdID)
+	}
+	if err := app.AddList(&list); err != nil {
+		return ListDiff{}, err
+	}
+	app.recordListDiff(diff)
+	app.refreshListQueues(list.Url)
+	return diff, nil
+}
+
+// Checks if the list header (scraped from the first page of the list) shows
+// that the list has been changed since it was last scraped.
+func (fl *FilmList) changedFrom(header FilmList) bool {
+	if !header.Updated.IsZero() {
+		return !header.Updated.Equal(fl.Updated)
+	}
+	return header.NumFilms >= 0 && header.NumFilms != fl.NumFilms // fall back on film count
+}
+
+// Checks if tracked list has changed on Letterboxd since it was last scraped.
+func (app *Application) listChanged(fl *FilmList) (bool, error) {
+	if fl.Kind != KindList { // other pages don't show when they were updated
+		return false, nil
+	}
+	header, err := ScrapeListHeader(fl.Url)
+	if err != nil {
+		return false, err
+	}
+	return fl.changedFrom(header), nil
+}
+
+// Note on a list entry.
+type ListNote struct {
+	List string // name of list
+	Note string
+}
+
+// Notes left on the film in tracked lists, sorted by list name.
+func (app *Application) ListNotes(film Film) []ListNote {
+	notes := make([]ListNote, 0)
+	for _, fl := range app.TrackedLists {
+		if note, ok := fl.Notes[film.LBxdID]; ok {
+			notes = append(notes, ListNote{List: fl.Name, Note: note})
+		}
+	}
+	slices.SortFunc(notes, func(a, b ListNote) int { return strings.Compare(a.List, b.List) })
+	return notes
 }
 
 // Checks if list is traced by user.
@@ -120,12 +207,65 @@ func (app *Application) AddListFromUrl(url string) error {
 	if _, ok := app.TrackedLists[url]; ok {
 		return ErrDuplicateList
 	}
-	if !strings.Contains(url, "/list/") {
-		return fmt.Errorf("%w, not a regular letterboxd list", ErrInvalidUrl)
-	}
-	list, err := ScrapeFilmList(url)
+	list, err := scrapeTrackedList(url)
 	if err != nil {
-		return fmt.Errorf("could not add list %s, %w", list.Name, err)
+		return err
 	}
 	return app.AddList(&list)
 }
+
+// Scrapes list (along with its entry notes) to be tracked. Filmography,
+// collection, and browse pages are scraped as lists as well (see ListKind).
+func scrapeTrackedList(rawURL string) (FilmList, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return FilmList{}, fmt.Errorf("%w, %w", ErrInvalidUrl, err)
+	}
+	kind, err := listKind(u)
+	if err != nil {
+		return FilmList{}, err
+	}
+	if kind != KindList {
+		return scrapePage(u, kind)
+	}
+	list, err := ScrapeFilmList(rawURL)
+	if err != nil {
+		return FilmList{}, fmt.Errorf("could not add list %s, %w", list.Name, err)
+	}
+	if list.Notes, err = ScrapeListNotes(rawURL); err != nil {
+		log.Printf("could not scrape notes for list %s, %s", list.Name, err)
+	}
+	return list, nil
+}
+
+// Scrapes a filmography, collection, or browse page as a list.
+func scrapePage(u *url.URL, kind ListKind) (FilmList, error) {
+	var stop func([]*Film) bool
+	if kind == KindBrowse {
+		scraped := 0
+		stop = func(page []*Film) bool {
+			scraped += len(page)
+			return scraped >= maxBrowseFilms
+		}
+	}
+	list, err := scrapeFilmList(scrapeUrl(u, kind), stop)
+	if err != nil {
+		return FilmList{}, fmt.Errorf("could not add %s %s, %w", kind, u, err)
+	}
+	list.Url, list.Kind = u.String(), kind
+	list.NumFilms = len(list.Films)
+	list.Ordered = kind.defaultOrdered()
+	if list.Name == "" {
+		list.Name = pageName(u, kind)
+	}
+	return list, nil
+}
+
+func findFilm(films []*Film, id int) *Film {
+	for _, f := range films {
+		if f.LBxdID == id {
+			return f
+		}
+	}
+	return nil
+}
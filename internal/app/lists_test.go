@@ -2,7 +2,10 @@ package app
 
 import (
 	"errors"
+	"net/url"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestFilmListNextWatch(t *testing.T) {
@@ -171,7 +174,7 @@ func TestApplicationRefreshList(t *testing.T) {
 			if err := tc.setup(app, tc.list); err != nil {
 				t.Fatalf("setup: %v", err)
 			}
-			err := app.RefreshList(tc.list)
+			_, err := app.RefreshList(tc.list)
 			if tc.wantErr == nil {
 				if err != nil {
 					t.Fatalf("unexpected error %v", err)
@@ -196,3 +199,159 @@ func TestApplicationRefreshList(t *testing.T) {
 		})
 	}
 }
+
+func TestFilmListChangedFrom(t *testing.T) {
+	updated := time.Date(2024, 3, 11, 8, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name   string
+		list   FilmList
+		header FilmList
+		want   bool
+	}{
+		{
+			name:   "unchanged",
+			list:   FilmList{NumFilms: 10, Updated: updated},
+			header: FilmList{NumFilms: 10, Updated: updated},
+		},
+		{
+			name:   "updated",
+			list:   FilmList{NumFilms: 10, Updated: updated},
+			header: FilmList{NumFilms: 10, Updated: updated.Add(time.Hour)},
+			want:   true,
+		},
+		{
+			name:   "count differs but date unchanged",
+			list:   FilmList{NumFilms: 98, Updated: updated},
+			header: FilmList{NumFilms: 100, Updated: updated},
+		},
+		{
+			name:   "no date, count differs",
+			list:   FilmList{NumFilms: 10},
+			header: FilmList{NumFilms: 11},
+			want:   true,
+		},
+		{
+			name:   "no date or count",
+			list:   FilmList{NumFilms: 10},
+			header: FilmList{NumFilms: -1},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.list.changedFrom(test.header); got != test.want {
+				t.Errorf("want %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestApplicationListNotes(t *testing.T) {
+	film := Film{LBxdID: 1, Title: "Noted"}
+	app := &Application{TrackedLists: map[string]*FilmList{
+		"b": {Name: "B List", Notes: map[int]string{1: "second"}},
+		"a": {Name: "A List", Notes: map[int]string{1: "first", 2: "other film"}},
+		"c": {Name: "C List"},
+	}}
+	want := []ListNote{{List: "A List", Note: "first"}, {List: "B List", Note: "second"}}
+	if got := app.ListNotes(film); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestListKind(t *testing.T) {
+	testCases := []struct {
+		url      string
+		want     ListKind
+		wantErr  error
+		wantUrl  string
+		wantName string
+	}{
+		{
+			url:     "https://letterboxd.com/oscars/list/the-96th-academy-award-nominees-for-best/",
+			want:    KindList,
+			wantUrl: "https://letterboxd.com/oscars/list/the-96th-academy-award-nominees-for-best/",
+		},
+		{
+			url:      "https://letterboxd.com/director/christopher-nolan/",
+			want:     KindFilmography,
+			wantUrl:  "https://letterboxd.com/director/christopher-nolan/by/release-earliest/",
+			wantName: "Director: Christopher Nolan",
+		},
+		{
+			url:      "https://letterboxd.com/actor/toni-collette/by/rating/",
+			want:     KindFilmography,
+			wantUrl:  "https://letterboxd.com/actor/toni-collette/by/rating/",
+			wantName: "Actor: Toni Collette",
+		},
+		{
+			url:      "https://letterboxd.com/films/in/the-godfather-collection/",
+			want:     KindCollection,
+			wantUrl:  "https://letterboxd.com/films/in/the-godfather-collection/by/release-earliest/",
+			wantName: "The Godfather Collection",
+		},
+		{
+			url:      "https://letterboxd.com/films/genre/horror/",
+			want:     KindBrowse,
+			wantUrl:  "https://letterboxd.com/films/ajax/genre/horror/",
+			wantName: "Films: Genre Horror",
+		},
+		{url: "https://letterboxd.com/film/barbie/", wantErr: ErrInvalidUrl},
+		{
+			url:      "https://letterboxd.com/jsdoublel/watchlist/",
+			want:     KindWatchlist,
+			wantUrl:  "https://letterboxd.com/jsdoublel/watchlist/",
+			wantName: "jsdoublel's Watchlist",
+		},
+		{
+			url:      "https://letterboxd.com/jsdoublel/likes/films/",
+			want:     KindLikes,
+			wantUrl:  "https://letterboxd.com/jsdoublel/likes/films/",
+			wantName: "jsdoublel's Liked Films",
+		},
+		{url: "https://letterboxd.com/jsdoublel/likes/reviews/", wantErr: ErrInvalidUrl},
+		{url: "https://example.com/director/christopher-nolan/", wantErr: ErrInvalidUrl},
+	}
+	for _, test := range testCases {
+		t.Run(test.url, func(t *testing.T) {
+			u, err := url.Parse(test.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			kind, err := listKind(u)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("expected error %v, got %v", test.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if kind != test.want {
+				t.Errorf("want kind %s, got %s", test.want, kind)
+			}
+			if got := scrapeUrl(u, kind); got != test.wantUrl {
+				t.Errorf("want url %s, got %s", test.wantUrl, got)
+			}
+			if got := pageName(u, kind); got != test.wantName {
+				t.Errorf("want name %q, got %q", test.wantName, got)
+			}
+		})
+	}
+}
+
+func TestMemberPages(t *testing.T) {
+	pages := MemberPages("partner")
+	if len(pages) != 2 {
+		t.Fatalf("expected 2 pages, got %d", len(pages))
+	}
+	for _, page := range pages {
+		u, err := url.Parse(page.Url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kind, err := listKind(u); err != nil || kind != page.Kind {
+			t.Errorf("page %s has kind %s, url parsed as %s (err %v)", page.Url, page.Kind, kind, err)
+		}
+		if page.NumFilms >= 0 || page.Films != nil {
+			t.Errorf("expected page %s without films", page.Url)
+		}
+	}
+}
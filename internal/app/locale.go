@@ -0,0 +1,117 @@
+package app
+
+import (
+	"maps"
+	"strings"
+	"time"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+// TMDB release types counted as a film being out in the configured region
+// (see https://developer.themoviedb.org/reference/movie-release-dates).
+const (
+	releaseTheatrical = 3
+	releaseDigital    = 4
+)
+
+// Adds configured language (and region if withRegion) to TMDB request
+// options.
+func localeOptions(opts map[string]string, withRegion bool) map[string]string {
+	opts = maps.Clone(opts)
+	if opts == nil {
+		opts = make(map[string]string)
+	}
+	if Config.TMDB.Language != "" {
+		opts["language"] = Config.TMDB.Language
+	}
+	if withRegion && Config.TMDB.Region != "" {
+		opts["region"] = strings.ToUpper(Config.TMDB.Region)
+	}
+	return opts
+}
+
+// Configured TMDB language and region, as saved with film records whose details
+// depend on them (titles, overviews, certifications, and release dates). Empty
+// if neither is set.
+func currentLocale() string {
+	if Config.TMDB.Language == "" && Config.TMDB.Region == "" {
+		return ""
+	}
+	return Config.TMDB.Language + "/" + strings.ToUpper(Config.TMDB.Region)
+}
+
+// Earliest theatrical or digital release of the film in region. Returns false
+// if there is none (or release dates were not fetched).
+func regionalReleaseDate(details *tmdb.MovieDetails, region string) (time.Time, bool) {
+	if region == "" || details == nil || details.MovieReleaseDatesAppend == nil ||
+		details.ReleaseDates == nil || details.ReleaseDates.MovieReleaseDatesResults == nil {
+		return time.Time{}, false
+	}
+	var earliest time.Time
+	for _, res := range details.ReleaseDates.Results {
+		if !strings.EqualFold(res.Iso3166_1, region) {
+			continue
+		}
+		for _, rd := range res.ReleaseDates {
+			if rd.Type != releaseTheatrical && rd.Type != releaseDigital {
+				continue
+			}
+			date, err := time.Parse(time.RFC3339, rd.ReleaseDate)
+			if err != nil {
+				continue
+			}
+			if earliest.IsZero() || date.Before(earliest) {
+				earliest = date
+			}
+		}
+	}
+	return earliest, !earliest.IsZero()
+}
+
+// Drops release dates for regions other than the configured one, since
+// details are saved and TMDB returns release dates for every region.
+func trimReleaseDates(details *tmdb.MovieDetails, region string) {
+	if details.MovieReleaseDatesAppend == nil || details.ReleaseDates == nil ||
+		details.ReleaseDates.MovieReleaseDatesResults == nil {
+		return
+	}
+	kept := details.ReleaseDates.Results[:0]
+	for _, res := range details.ReleaseDates.Results {
+		if strings.EqualFold(res.Iso3166_1, region) {
+			kept = append(kept, res)
+		}
+	}
+	details.ReleaseDates.Results = kept
+}
+
+// Age certification (e.g., "PG-13") of the film in the configured region,
+// preferring the theatrical release's. Empty if unknown.
+func (fr *FilmRecord) Certification() string {
+	return certification(fr.Details, Config.TMDB.Region)
+}
+
+func certification(details *tmdb.MovieDetails, region string) string {
+	if region == "" || details == nil || details.MovieReleaseDatesAppend == nil ||
+		details.ReleaseDates == nil || details.ReleaseDates.MovieReleaseDatesResults == nil {
+		return ""
+	}
+	cert := ""
+	for _, res := range details.ReleaseDates.Results {
+		if !strings.EqualFold(res.Iso3166_1, region) {
+			continue
+		}
+		for _, rd := range res.ReleaseDates {
+			if rd.Certification == "" {
+				continue
+			}
+			if rd.Type == releaseTheatrical {
+				return rd.Certification
+			}
+			if cert == "" {
+				cert = rd.Certification
+			}
+		}
+	}
+	return cert
+}
@@ -0,0 +1,139 @@
+package app
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+func testReleaseDetails(t *testing.T) *tmdb.MovieDetails {
+	t.Helper()
+	var dates tmdb.MovieReleaseDates
+	err := json.Unmarshal([]byte(`{"results": [
+		{"iso_3166_1": "US", "release_dates": [
+			{"release_date": "2019-05-30T00:00:00.000Z", "type": 1},
+			{"release_date": "2019-10-11T00:00:00.000Z", "type": 3, "certification": "R"},
+			{"release_date": "2020-01-28T00:00:00.000Z", "type": 4}
+		]},
+		{"iso_3166_1": "KR", "release_dates": [
+			{"release_date": "2019-05-30T00:00:00.000Z", "type": 3}
+		]},
+		{"iso_3166_1": "GB", "release_dates": [
+			{"release_date": "2019-06-05T00:00:00.000Z", "type": 5, "certification": "15"}
+		]},
+		{"iso_3166_1": "FR", "release_dates": [
+			{"release_date": "2019-05-21T00:00:00.000Z", "type": 1},
+			{"release_date": "2019-06-05T00:00:00.000Z", "type": 5, "certification": "TP"}
+		]}
+	]}`), &dates)
+	if err != nil {
+		t.Fatalf("could not unmarshal release dates, %s", err)
+	}
+	return &tmdb.MovieDetails{ReleaseDate: "2019-05-30", MovieReleaseDatesAppend: &tmdb.MovieReleaseDatesAppend{ReleaseDates: &dates}}
+}
+
+func TestRegionalReleaseDate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		region string
+		want   time.Time
+		wantOk bool
+	}{
+		{name: "theatrical before digital", region: "us", want: time.Date(2019, 10, 11, 0, 0, 0, 0, time.UTC), wantOk: true},
+		{name: "other region", region: "KR", want: time.Date(2019, 5, 30, 0, 0, 0, 0, time.UTC), wantOk: true},
+		{name: "only premiere in region", region: "FR"},
+		{name: "no data for region", region: "DE"},
+		{name: "no region configured"},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := regionalReleaseDate(testReleaseDetails(t), test.region)
+			if ok != test.wantOk {
+				t.Fatalf("want ok %t, got %t", test.wantOk, ok)
+			}
+			if !got.Equal(test.want) {
+				t.Errorf("want %s, got %s", test.want, got)
+			}
+		})
+	}
+	if _, ok := regionalReleaseDate(&tmdb.MovieDetails{}, "US"); ok {
+		t.Error("expected no release date when release dates were not fetched")
+	}
+}
+
+func TestTrimReleaseDates(t *testing.T) {
+	details := testReleaseDetails(t)
+	trimReleaseDates(details, "kr")
+	if results := details.ReleaseDates.Results; len(results) != 1 || results[0].Iso3166_1 != "KR" {
+		t.Errorf("expected only KR release dates to be kept, got %v", results)
+	}
+}
+
+func TestLocaleOptions(t *testing.T) {
+	prev := Config.TMDB
+	t.Cleanup(func() { Config.TMDB = prev })
+	Config.TMDB = tmdbConfig{Region: "gb", Language: "en-GB"}
+	opts := map[string]string{"page": "1"}
+	if got, want := localeOptions(opts, true), map[string]string{"page": "1", "language": "en-GB", "region": "GB"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	if got, want := localeOptions(opts, false), map[string]string{"page": "1", "language": "en-GB"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	if len(opts) != 1 {
+		t.Errorf("expected options passed in to be left as is, got %v", opts)
+	}
+	Config.TMDB = tmdbConfig{}
+	if got := localeOptions(nil, true); len(got) != 0 {
+		t.Errorf("expected no options without locale config, got %v", got)
+	}
+}
+
+func TestCertification(t *testing.T) {
+	testCases := []struct {
+		name   string
+		region string
+		want   string
+	}{
+		{name: "theatrical certification", region: "us", want: "R"},
+		{name: "home release certification", region: "GB", want: "15"},
+		{name: "no certification", region: "KR"},
+		{name: "no data for region", region: "DE"},
+		{name: "no region configured"},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			if got := certification(testReleaseDetails(t), test.region); got != test.want {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestFilmRecordExpiredLocale(t *testing.T) {
+	prev := Config.TMDB
+	t.Cleanup(func() { Config.TMDB = prev })
+	testCases := []struct {
+		name   string
+		config tmdbConfig
+		locale string
+		want   bool
+	}{
+		{name: "no locale", locale: "", want: false},
+		{name: "same locale", config: tmdbConfig{Language: "en-GB", Region: "gb"}, locale: "en-GB/GB", want: false},
+		{name: "language changed", config: tmdbConfig{Language: "fr-FR", Region: "gb"}, locale: "en-GB/GB", want: true},
+		{name: "region set", config: tmdbConfig{Region: "kr"}, locale: "", want: true},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			Config.TMDB = test.config
+			fr := FilmRecord{Checked: time.Now(), Locale: test.locale}
+			if got := fr.Expired(); got != test.want {
+				t.Errorf("want expired %t, got %t", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const localListPrefix = "local:" // prefix of the urls used as keys for local lists
+
+var (
+	ErrInvalidListName = errors.New("invalid list name")
+	ErrFilmInList      = errors.New("film already in list")
+)
+
+// Key local list is tracked under in TrackedLists.
+func LocalListUrl(name string) string {
+	return localListPrefix + strings.TrimSpace(name)
+}
+
+// Lists kept in nw only (e.g., films picked from recommendations), sorted by
+// name.
+func (app *Application) LocalLists() []*FilmList {
+	lists := make([]*FilmList, 0)
+	for _, fl := range app.TrackedLists {
+		if fl.Kind == KindLocal {
+			lists = append(lists, fl)
+		}
+	}
+	slices.SortFunc(lists, func(a, b *FilmList) int { return strings.Compare(a.Name, b.Name) })
+	return lists
+}
+
+// Adds film to the named local list, making the list if it does not exist.
+func (app *Application) AddToLocalList(name string, film Film) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("%w, name cannot be empty", ErrInvalidListName)
+	}
+	fl, ok := app.TrackedLists[LocalListUrl(name)]
+	if !ok {
+		fl = &FilmList{Name: name, Url: LocalListUrl(name), Kind: KindLocal, Owner: app.Username, Films: []*Film{}}
+		if err := app.AddList(fl); err != nil {
+			return err
+		}
+	}
+	if findFilm(fl.Films, film.LBxdID) != nil {
+		return fmt.Errorf("%w, %s is already in %s", ErrFilmInList, film, name)
+	}
+	fl.Films = append(fl.Films, &film)
+	fl.NumFilms = len(fl.Films)
+	fl.Updated = time.Now()
+	app.FilmStore.register(film)
+	return nil
+}
+
+// Adds film found from its TMDB id (see ScrapeFilmFromTMDB) to the named local
+// list, keeping the id so it doesn't have to be scraped again.
+func (app *Application) AddTMDBFilmToLocalList(name string, film Film, tmdbID int) error {
+	if err := app.AddToLocalList(name, film); err != nil {
+		return err
+	}
+	app.FilmStore.setTMDBID(film.LBxdID, tmdbID)
+	return nil
+}
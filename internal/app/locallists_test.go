@@ -0,0 +1,77 @@
+package app
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAddToLocalList(t *testing.T) {
+	film := Film{LBxdID: 1, Title: "Dancer in the Dark", Year: 2000}
+	testCases := []struct {
+		name     string
+		list     string
+		existing []Film // films already in list
+		wantErr  error
+		wantLen  int
+	}{
+		{name: "new list", list: "Recommended", wantLen: 1},
+		{name: "existing list", list: "Recommended", existing: []Film{{LBxdID: 2}}, wantLen: 2},
+		{name: "already in list", list: "Recommended", existing: []Film{film}, wantErr: ErrFilmInList, wantLen: 1},
+		{name: "empty name", list: "  ", wantErr: ErrInvalidListName},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			app := &Application{
+				TrackedLists: make(map[string]*FilmList),
+				FilmStore:    FilmStore{Films: make(map[int]*FilmRecord)},
+			}
+			if test.existing != nil {
+				fl := &FilmList{Name: test.list, Url: LocalListUrl(test.list), Kind: KindLocal}
+				for _, f := range test.existing {
+					fl.Films = append(fl.Films, &f)
+				}
+				if err := app.AddList(fl); err != nil {
+					t.Fatalf("could not add list, %s", err)
+				}
+			}
+			err := app.AddToLocalList(test.list, film)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("expected error %v, got %v", test.wantErr, err)
+			}
+			lists := app.LocalLists()
+			if test.wantLen == 0 {
+				if len(lists) != 0 {
+					t.Errorf("expected no local lists, got %d", len(lists))
+				}
+				return
+			}
+			if len(lists) != 1 || len(lists[0].Films) != test.wantLen {
+				t.Fatalf("expected one local list with %d films, got %+v", test.wantLen, lists)
+			}
+			if refs := app.FilmStore.Films[film.LBxdID].NRefs; refs != 1 {
+				t.Errorf("expected film to have 1 ref, got %d", refs)
+			}
+			diff, err := app.RefreshList(lists[0])
+			if err != nil || !diff.Empty() || !app.IsListTracked(LocalListUrl(test.list)) {
+				t.Errorf("expected refreshing local list to do nothing, got diff %v, err %v", diff, err)
+			}
+		})
+	}
+}
+
+func TestAddTMDBFilmToLocalList(t *testing.T) {
+	film := Film{LBxdID: 1, Title: "Dancer in the Dark", Year: 2000}
+	app := &Application{
+		TrackedLists: make(map[string]*FilmList),
+		FilmStore:    FilmStore{Films: map[int]*FilmRecord{1: {Film: film, NRefs: 1}}},
+	}
+	if err := app.AddTMDBFilmToLocalList("Recommended", film, 16); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if fl := app.TrackedLists[LocalListUrl("Recommended")]; fl == nil || findFilm(fl.Films, film.LBxdID) == nil {
+		t.Errorf("expected %s to be added to list", film)
+	}
+	if index := app.FilmStore.TMDBIndex(); len(index) != 1 || index[16] != film {
+		t.Errorf("unexpected tmdb index %v", index)
+	}
+}
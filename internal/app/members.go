@@ -0,0 +1,208 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidUsername    = errors.New("invalid username")
+	ErrMemberListsMissing = errors.New("member's lists have not been loaded")
+)
+
+// Another Letterboxd member whose lists can be browsed and tracked.
+type Member struct {
+	Lists     []*FilmList // member's lists on letterboxd (without scraped films)
+	Checked   time.Time   // last time member's lists were scraped
+	Following bool        // member's lists are kept up to date and shown with user's lists
+}
+
+// Gets lists belonging to a Letterboxd member. Lists are cached (see
+// userDataExpireTime), and the cached lists are used if the member's lists
+// cannot be scraped because of the network.
+func (app *Application) MemberLists(username string) ([]*FilmList, error) {
+	if lists, ok := app.SavedMemberLists(username); ok {
+		return lists, nil
+	}
+	return app.ApplyMemberLists(ScrapeMemberLists(username))
+}
+
+// Member's saved lists, if they can be used without scraping them again (they
+// have not expired or nw is offline). The user's own lists are always saved.
+func (app *Application) SavedMemberLists(username string) ([]*FilmList, bool) {
+	username, err := cleanUsername(username)
+	if err != nil {
+		return nil, false
+	}
+	if username == strings.ToLower(app.Username) {
+		return app.ListHeaders, true
+	}
+	m, ok := app.Members[username]
+	if ok && (time.Since(m.Checked) < userDataExpireTime() || Offline()) {
+		return m.Lists, true
+	}
+	return nil, false
+}
+
+// Lists of a member scraped in the background, which are stored when applied
+// (see ApplyMemberLists).
+type MemberListsScrape struct {
+	username string
+	lists    []*FilmList
+	err      error
+}
+
+// Scrapes member's lists. Nothing is stored, so this is safe to run in the
+// background.
+func ScrapeMemberLists(username string) MemberListsScrape {
+	username, err := cleanUsername(username)
+	if err != nil {
+		return MemberListsScrape{err: err}
+	}
+	lists, err := ScrapeUserLists(username)
+	return MemberListsScrape{username: username, lists: lists, err: err}
+}
+
+// Stores scraped lists, returning them. If scraping failed because of the
+// network, the member's saved lists are returned instead (if there are any).
+func (app *Application) ApplyMemberLists(s MemberListsScrape) ([]*FilmList, error) {
+	if s.err != nil {
+		if m, ok := app.Members[s.username]; ok && isNetworkError(s.err) {
+			log.Printf("could not update lists of %s, using saved lists, %s", s.username, s.err)
+			return m.Lists, nil
+		}
+		return nil, s.err
+	}
+	app.setMemberLists(s.username, s.lists)
+	return s.lists, nil
+}
+
+// Follows member so that their lists are shown with the user's own lists and
+// new lists appear automatically when user data is updated. The member's lists
+// must already be saved (see MemberLists), as they are when browsing them.
+func (app *Application) FollowMember(username string) error {
+	username, err := cleanUsername(username)
+	if err != nil {
+		return fmt.Errorf("could not follow %s, %w", username, err)
+	}
+	if username == strings.ToLower(app.Username) {
+		return fmt.Errorf("could not follow %s, %w, cannot follow yourself", username, ErrInvalidUsername)
+	}
+	m, ok := app.Members[username]
+	if !ok {
+		return fmt.Errorf("could not follow %s, %w", username, ErrMemberListsMissing)
+	}
+	m.Following = true
+	return nil
+}
+
+// Stops following member.
+func (app *Application) UnfollowMember(username string) {
+	username, _ = cleanUsername(username)
+	if m, ok := app.Members[username]; ok {
+		m.Following = false
+	}
+}
+
+// Checks if user follows member.
+func (app *Application) IsFollowing(username string) bool {
+	username, _ = cleanUsername(username)
+	m, ok := app.Members[username]
+	return ok && m.Following
+}
+
+// Lists of all followed members, sorted by member.
+func (app *Application) FollowedLists() []*FilmList {
+	usernames := make([]string, 0, len(app.Members))
+	for username, m := range app.Members {
+		if m.Following {
+			usernames = append(usernames, username)
+		}
+	}
+	slices.Sort(usernames)
+	lists := make([]*FilmList, 0)
+	for _, username := range usernames {
+		lists = append(lists, app.Members[username].Lists...)
+	}
+	return lists
+}
+
+// Scrapes member's lists (see setMemberLists).
+func (app *Application) updateMember(username string) error {
+	lists, err := ScrapeUserLists(username)
+	if err != nil {
+		return err
+	}
+	app.setMemberLists(username, lists)
+	return nil
+}
+
+// Saves member's lists, logging lists that are new since they were last saved.
+func (app *Application) setMemberLists(username string, lists []*FilmList) {
+	if app.Members == nil {
+		app.Members = make(map[string]*Member)
+	}
+	m, ok := app.Members[username]
+	if !ok {
+		m = &Member{}
+		app.Members[username] = m
+	} else if n := len(newLists(m.Lists, lists)); n > 0 {
+		log.Printf("%s has %d new lists", username, n)
+	}
+	m.Lists, m.Checked = lists, time.Now()
+}
+
+// Updates lists of followed members and drops cached lists of members that
+// are not followed once they expire.
+func (app *Application) updateMembers() error {
+	var lastErr error
+	for username, m := range app.Members {
+		if !m.Following {
+			if time.Since(m.Checked) > userDataExpireTime() {
+				delete(app.Members, username)
+			}
+			continue
+		}
+		if err := app.updateMember(username); err != nil {
+			if isNetworkError(err) {
+				return err
+			}
+			lastErr = fmt.Errorf("could not update lists of %s, %w", username, err)
+		}
+	}
+	return lastErr
+}
+
+// Lists in cur that are not in prev.
+func newLists(prev, cur []*FilmList) []*FilmList {
+	known := make(map[string]bool, len(prev))
+	for _, fl := range prev {
+		known[fl.Url] = true
+	}
+	added := make([]*FilmList, 0)
+	for _, fl := range cur {
+		if !known[fl.Url] {
+			added = append(added, fl)
+		}
+	}
+	return added
+}
+
+// Normalizes username entered by the user (e.g., "@Name" or
+// "letterboxd.com/name/").
+func cleanUsername(username string) (string, error) {
+	username = strings.TrimSpace(username)
+	username = strings.TrimPrefix(username, "@")
+	for _, prefix := range []string{"https://", "http://", "letterboxd.com/"} {
+		username = strings.TrimPrefix(username, prefix)
+	}
+	username = strings.ToLower(strings.Trim(username, "/"))
+	if username == "" || strings.ContainsAny(username, "/?# ") {
+		return "", fmt.Errorf("%w, %q", ErrInvalidUsername, username)
+	}
+	return username, nil
+}
@@ -0,0 +1,163 @@
+package app
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCleanUsername(t *testing.T) {
+	testCases := []struct {
+		input   string
+		want    string
+		wantErr error
+	}{
+		{input: "jsdoublel", want: "jsdoublel"},
+		{input: " @JSDoubleL ", want: "jsdoublel"},
+		{input: "https://letterboxd.com/jsdoublel/", want: "jsdoublel"},
+		{input: "letterboxd.com/jsdoublel", want: "jsdoublel"},
+		{input: "@", wantErr: ErrInvalidUsername},
+		{input: "jsdoublel/lists", wantErr: ErrInvalidUsername},
+	}
+	for _, test := range testCases {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := cleanUsername(test.input)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("expected error %v, got %v", test.wantErr, err)
+			}
+			if got != test.want {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestMemberLists(t *testing.T) {
+	own := []*FilmList{{Name: "Mine", Url: "https://letterboxd.com/me/list/mine/"}}
+	cached := []*FilmList{{Name: "Theirs", Url: "https://letterboxd.com/them/list/theirs/"}}
+	app := &Application{
+		Username:    "me",
+		ListHeaders: own,
+		Members: map[string]*Member{
+			"them": {Lists: cached, Checked: time.Now()},
+		},
+	}
+	testCases := []struct {
+		name     string
+		username string
+		want     []*FilmList
+	}{
+		{name: "own lists", username: "@Me", want: own},
+		{name: "cached lists", username: "@them", want: cached},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := app.MemberLists(test.username)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestFollowedLists(t *testing.T) {
+	a := &FilmList{Url: "a"}
+	b := &FilmList{Url: "b"}
+	c := &FilmList{Url: "c"}
+	app := &Application{Members: map[string]*Member{
+		"zed":   {Lists: []*FilmList{c}, Following: true},
+		"amy":   {Lists: []*FilmList{a, b}, Following: true},
+		"other": {Lists: []*FilmList{{Url: "d"}}},
+	}}
+	if got, want := app.FollowedLists(), []*FilmList{a, b, c}; !reflect.DeepEqual(want, got) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+	if !app.IsFollowing("@Amy") || app.IsFollowing("other") || app.IsFollowing("nobody") {
+		t.Errorf("unexpected following status")
+	}
+	app.UnfollowMember("amy")
+	if app.IsFollowing("amy") {
+		t.Errorf("expected amy to be unfollowed")
+	}
+}
+
+func TestUpdateMembersPrunes(t *testing.T) {
+	app := &Application{Members: map[string]*Member{
+		"stale": {Checked: time.Now().Add(-2 * userDataExpireTime())},
+		"fresh": {Checked: time.Now()},
+	}}
+	if err := app.updateMembers(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, ok := app.Members["stale"]; ok {
+		t.Errorf("expected stale member to be dropped")
+	}
+	if _, ok := app.Members["fresh"]; !ok {
+		t.Errorf("expected fresh member to be kept")
+	}
+}
+
+func TestNewLists(t *testing.T) {
+	a, b, c := &FilmList{Url: "a"}, &FilmList{Url: "b"}, &FilmList{Url: "c"}
+	if got, want := newLists([]*FilmList{a, b}, []*FilmList{c, a, b}), []*FilmList{c}; !reflect.DeepEqual(want, got) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestApplyMemberLists(t *testing.T) {
+	saved := []*FilmList{{Name: "Saved", Url: "https://letterboxd.com/them/list/saved/"}}
+	scraped := []*FilmList{{Name: "Scraped", Url: "https://letterboxd.com/them/list/scraped/"}}
+	testCases := []struct {
+		name    string
+		scrape  MemberListsScrape
+		want    []*FilmList
+		wantErr error
+	}{
+		{name: "stores scraped lists", scrape: MemberListsScrape{username: "them", lists: scraped}, want: scraped},
+		{name: "uses saved lists when offline", scrape: MemberListsScrape{username: "them", err: ErrNetwork}, want: saved},
+		{name: "fails without saved lists", scrape: MemberListsScrape{username: "other", err: ErrNetwork}, wantErr: ErrNetwork},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			app := &Application{Members: map[string]*Member{"them": {Lists: saved}}}
+			got, err := app.ApplyMemberLists(test.scrape)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("expected error %v, got %v", test.wantErr, err)
+			}
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %+v, got %+v", test.want, got)
+			}
+			if err == nil && !reflect.DeepEqual(app.Members["them"].Lists, test.want) {
+				t.Errorf("expected saved lists %+v, got %+v", test.want, app.Members["them"].Lists)
+			}
+		})
+	}
+}
+
+func TestFollowMember(t *testing.T) {
+	testCases := []struct {
+		name     string
+		username string
+		wantErr  error
+	}{
+		{name: "saved member", username: "@Them"},
+		{name: "member not loaded", username: "other", wantErr: ErrMemberListsMissing},
+		{name: "user", username: "me", wantErr: ErrInvalidUsername},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			app := &Application{Username: "me", Members: map[string]*Member{"them": {}}}
+			err := app.FollowMember(test.username)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("expected error %v, got %v", test.wantErr, err)
+			}
+			if got := app.IsFollowing(test.username); got != (test.wantErr == nil) {
+				t.Errorf("expected following to be %t, got %t", test.wantErr == nil, got)
+			}
+		})
+	}
+}
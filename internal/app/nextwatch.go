@@ -25,6 +25,8 @@ type NextWatch struct {
 	watchedFilms FilmsSet
 	watchlist    FilmsSet
 	store        *FilmStore
+	filters      *QueueFilters // extra rules films must pass (nil for none)
+	matcher      *FilmMatcher  // matches collection parts to watched films (built when needed)
 }
 
 // Create NextWatch queue data structure, selecting NumberOfStacks*StackSize+1
@@ -33,6 +35,17 @@ type NextWatch struct {
 //
 // Returns an error if there is not enough unwatched films in the watchlist.
 func (app *Application) MakeNextWatch() (NextWatch, error) {
+	return app.makeNextWatchFrom(app.Watchlist)
+}
+
+// Create NextWatch queue that selects films from pool instead of the
+// watchlist.
+func (app *Application) makeNextWatchFrom(pool FilmsSet) (NextWatch, error) {
+	return app.makeFilteredNextWatch(pool, nil)
+}
+
+// Create NextWatch queue that selects films from pool that pass filters.
+func (app *Application) makeFilteredNextWatch(pool FilmsSet, filters *QueueFilters) (NextWatch, error) {
 	stacks := make([][]*Film, NumberOfStacks+1)
 	stacks[0] = make([]*Film, 1)
 	for i := range NumberOfStacks {
@@ -41,8 +54,9 @@ func (app *Application) MakeNextWatch() (NextWatch, error) {
 	nw := NextWatch{
 		Stacks:       stacks,
 		watchedFilms: app.WatchedFilms,
-		watchlist:    app.Watchlist,
+		watchlist:    pool,
 		store:        &app.FilmStore,
+		filters:      filters,
 	}
 	nw.makeLastUpdate()
 	if err := nw.update(); err != nil {
@@ -91,6 +105,7 @@ func (nw *NextWatch) update() error {
 		return nil
 	}
 	nw.ClearLastUpdated()
+	nw.matcher = nil // watched films may have changed since last update
 	pool := make([]*Film, 0, len(nw.watchlist))
 	for _, f := range nw.watchlist {
 		if !nw.watchedFilms.InSet(f) && !nw.ContainsFilm(*f) {
@@ -142,10 +157,22 @@ func (nw *NextWatch) filterFilm(film Film) bool {
 		log.Printf("%s, excluding film %s", err, film)
 		return false
 	}
-	if errors.Is(err, ErrNoAPI) {
+	if errors.Is(err, ErrNoAPI) || errors.Is(err, ErrOffline) {
+		if nw.filters != nil && !nw.filters.match(film, nil) {
+			log.Printf("excluding film %s, it does not match queue filters", film)
+			return false
+		}
+		if holdBackSequel(nw.store, film, nw.filmMatcher, nw.watchedFilms) {
+			log.Printf("excluding film %s, earlier films in its collection have not been watched", film)
+			return false
+		}
 		log.Printf("%s, proceeding without checks to add film %s to next watch queue", err, film)
 		return true
 	}
+	if err != nil || f == nil {
+		log.Printf("could not get details for %s, %v, excluding film", film, err)
+		return false
+	}
 	if f.ReleaseDate.IsZero() {
 		log.Printf("invalid release date for %s, %s, excluding film", film, err)
 		return false
@@ -154,10 +181,25 @@ func (nw *NextWatch) filterFilm(film Film) bool {
 		log.Printf("excluding film %s, it has not been released", film)
 		return false
 	}
+	if nw.filters != nil && !nw.filters.match(film, f.Details) {
+		log.Printf("excluding film %s, it does not match queue filters", film)
+		return false
+	}
+	if holdBackSequel(nw.store, film, nw.filmMatcher, nw.watchedFilms) {
+		log.Printf("excluding film %s, earlier films in %s have not been watched", film, f.Collection.Name)
+		return false
+	}
 	log.Printf("%s added to next watch queue", film)
 	return true
 }
 
+func (nw *NextWatch) filmMatcher() *FilmMatcher {
+	if nw.matcher == nil {
+		nw.matcher = newFilmMatcher(nw.store, nw.watchedFilms)
+	}
+	return nw.matcher
+}
+
 // Checks if all stack positions have a film in them
 func (nw *NextWatch) Full() bool {
 	for i, j := range nw.Positions() {
@@ -178,6 +220,17 @@ func (nw *NextWatch) ContainsFilm(film Film) bool {
 	return false
 }
 
+// Films in the queue, from the top down (skipping empty spots).
+func (nw *NextWatch) Films() []*Film {
+	films := make([]*Film, 0, NumberOfStacks*StackSize+1)
+	for i, j := range nw.Positions() {
+		if nw.Stacks[i][j] != nil {
+			films = append(films, nw.Stacks[i][j])
+		}
+	}
+	return films
+}
+
 func (nw *NextWatch) LastUpdated(i, j int) bool {
 	return nw.lastUpdated[i][j]
 }
@@ -48,6 +48,7 @@ func seedFilmStore(t *testing.T, store *FilmStore, films map[int]*Film) {
 }
 
 func TestApplicationMakeNextWatch(t *testing.T) {
+	resetOffline(t)
 	testCases := []struct {
 		name      string
 		films     int
@@ -145,6 +146,7 @@ func TestApplicationMakeNextWatch(t *testing.T) {
 }
 
 func TestNextWatchDeleteFilm(t *testing.T) {
+	resetOffline(t)
 	testCases := []struct {
 		name       string
 		stackNum   int
@@ -180,6 +182,7 @@ func TestNextWatchDeleteFilm(t *testing.T) {
 }
 
 func TestNextWatchUpdateWatched(t *testing.T) {
+	resetOffline(t)
 	testCases := []struct {
 		name          string
 		watchedTarget bool
@@ -222,3 +225,15 @@ func TestNextWatchUpdateWatched(t *testing.T) {
 		})
 	}
 }
+
+func TestNextWatchFilterFilmLookupError(t *testing.T) {
+	resetOffline(t)
+	nw := NextWatch{store: &FilmStore{Films: map[int]*FilmRecord{}}}
+	film := Film{LBxdID: 1, Title: "Unknown", Url: "https://example.com/not-letterboxd"}
+	if nw.filterFilm(film) {
+		t.Errorf("expected film without details to be excluded")
+	}
+	if Offline() {
+		t.Errorf("expected failed scrape not to switch to offline mode")
+	}
+}
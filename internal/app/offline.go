@@ -0,0 +1,103 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"net"
+	"sync/atomic"
+)
+
+var (
+	ErrOffline = errors.New("offline")
+
+	offline      atomic.Bool // network calls are skipped while set
+	forceOffline bool        // offline mode requested by user, never go back online
+)
+
+// Put application in offline mode for the rest of the session. All network
+// calls are skipped and everything is served from the save.
+func ForceOffline() {
+	forceOffline = true
+	offline.Store(true)
+}
+
+// Reports whether network calls are currently being skipped, either because
+// offline mode was requested or because Letterboxd/TMDB were unreachable.
+func Offline() bool {
+	return offline.Load()
+}
+
+// Reports whether offline mode was requested by the user (as opposed to
+// entered automatically).
+func OfflineForced() bool {
+	return forceOffline
+}
+
+// Returns ErrOffline if network calls should be skipped.
+func checkOnline() error {
+	if offline.Load() {
+		return ErrOffline
+	}
+	return nil
+}
+
+// Switch to offline mode after a network failure.
+func goOffline(err error) {
+	if !offline.Swap(true) {
+		log.Printf("network unreachable, switching to offline mode, %s", err)
+	}
+}
+
+// Leave offline mode so the next network call is attempted again. Does nothing
+// if offline mode was requested by the user.
+func goOnline() {
+	if !forceOffline && offline.Swap(false) {
+		log.Print("leaving offline mode, retrying network")
+	}
+}
+
+// Checks if error was caused by the network being unreachable (or skipped).
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, ErrOffline) || errors.Is(err, ErrNetwork) || errors.As(err, &netErr)
+}
+
+// Falls back on saved data when offline. Returns an error if there is nothing
+// saved to fall back on.
+func (app *Application) useSavedData() error {
+	if app.NWQueue.Stacks == nil {
+		return fmt.Errorf("%w, no saved data for user %s", ErrOffline, app.Username)
+	}
+	log.Print("offline, using saved user data")
+	return nil
+}
+
+// Defer retrieving film details until connectivity returns. Must be called
+// with the store locked.
+func (fs *FilmStore) deferRetrieve(film Film) {
+	if fs.Deferred == nil {
+		fs.Deferred = make(map[int]Film)
+	}
+	fs.Deferred[film.LBxdID] = film
+}
+
+// Retrieve details for films whose lookups were deferred while offline. Stops
+// early if the network is still unreachable.
+func (fs *FilmStore) retrieveDeferred() {
+	fs.mu.Lock()
+	deferred := maps.Clone(fs.Deferred)
+	fs.mu.Unlock()
+	for id, film := range deferred {
+		if _, err := fs.retrieve(film); err != nil {
+			log.Printf("deferred retrieval of %s failed, %s", film, err)
+			if isNetworkError(err) {
+				return
+			}
+		}
+		fs.mu.Lock()
+		delete(fs.Deferred, id)
+		fs.mu.Unlock()
+	}
+}
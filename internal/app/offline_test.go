@@ -0,0 +1,153 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+// Starts test online and restores online mode when it finishes, so tests
+// that go offline do not affect those that run after them.
+func resetOffline(t *testing.T) {
+	t.Helper()
+	reset := func() {
+		offline.Store(false)
+		forceOffline = false
+	}
+	reset()
+	t.Cleanup(reset)
+}
+
+func TestIsNetworkError(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "offline",
+			err:  fmt.Errorf("wrapped, %w", ErrOffline),
+			want: true,
+		},
+		{
+			name: "url error",
+			err:  &url.Error{Op: "Get", URL: "https://letterboxd.com", Err: &net.DNSError{Err: "no such host"}},
+			want: true,
+		},
+		{
+			name: "bad scrape",
+			err:  fmt.Errorf("%w, missing id", ErrBadScrape),
+			want: false,
+		},
+		{
+			name: "plain error",
+			err:  errors.New("Not Found"),
+			want: false,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isNetworkError(test.err); got != test.want {
+				t.Fatalf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestFilmStoreLookupOffline(t *testing.T) {
+	testCases := []struct {
+		name     string
+		existing map[int]*FilmRecord
+		film     Film
+		wantErr  error
+	}{
+		{
+			name: "returns expired record",
+			existing: map[int]*FilmRecord{
+				1: {
+					Film:    Film{LBxdID: 1, Title: "Saved"},
+					Details: &tmdb.MovieDetails{ID: 1, Title: "Saved"},
+					NRefs:   1,
+					Checked: time.Now().Add(-filmExpireTime() - time.Hour),
+				},
+			},
+			film:    Film{LBxdID: 1, Url: "https://letterboxd.com/film/saved/"},
+			wantErr: nil,
+		},
+		{
+			name:     "fails without saved record",
+			existing: map[int]*FilmRecord{},
+			film:     Film{LBxdID: 2, Url: "https://letterboxd.com/film/unsaved/"},
+			wantErr:  ErrOffline,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			resetOffline(t)
+			ForceOffline()
+			fs := &FilmStore{Films: test.existing}
+			record, err := fs.Lookup(test.film)
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Fatalf("expected error %v, got %v", test.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if record.LBxdID != test.film.LBxdID {
+				t.Fatalf("expected record %d, got %d", test.film.LBxdID, record.LBxdID)
+			}
+			if _, ok := fs.Deferred[test.film.LBxdID]; !ok {
+				t.Fatalf("expected retrieval of %d to be deferred", test.film.LBxdID)
+			}
+		})
+	}
+}
+
+func TestUpdateUserDataOffline(t *testing.T) {
+	testCases := []struct {
+		name    string
+		stacks  [][]*Film
+		wantErr bool
+	}{
+		{
+			name:    "uses saved queue",
+			stacks:  [][]*Film{{{LBxdID: 1}}},
+			wantErr: false,
+		},
+		{
+			name:    "fails without saved data",
+			stacks:  nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			resetOffline(t)
+			ForceOffline()
+			app := &Application{
+				Username:     "offline",
+				TrackedLists: make(map[string]*FilmList),
+				FilmStore:    FilmStore{Films: map[int]*FilmRecord{}},
+				NWQueue:      NextWatch{Stacks: test.stacks},
+			}
+			err := app.UpdateUserData(false)
+			if test.wantErr {
+				if !errors.Is(err, ErrOffline) {
+					t.Fatalf("expected ErrOffline, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !Offline() {
+				t.Fatalf("manual update should not leave forced offline mode")
+			}
+		})
+	}
+}
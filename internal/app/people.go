@@ -0,0 +1,187 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+const TMDBPersonPathPrefix = "https://www.themoviedb.org/person/"
+
+// Crew jobs listed in a film's credits alongside its directors and cast.
+var creditedJobs = []string{"Screenplay", "Writer", "Director of Photography", "Original Music Composer", "Editor"}
+
+// Person credited on a film.
+type Credit struct {
+	PersonID int    // tmdb person id
+	Name     string // person's name
+	Role     string // job or character played
+}
+
+// Credits of film's directors, cast, and key crew (in that order).
+func (fr *FilmRecord) Credits() []Credit {
+	if fr.Details == nil || fr.Details.MovieCreditsAppend == nil || fr.Details.Credits.MovieCredits == nil {
+		return nil
+	}
+	credits := make([]Credit, 0)
+	crew := fr.Details.Credits.Crew
+	for _, c := range crew {
+		if strings.EqualFold(c.Job, "Director") {
+			credits = append(credits, Credit{PersonID: int(c.ID), Name: c.Name, Role: c.Job})
+		}
+	}
+	for _, c := range fr.Details.Credits.Cast {
+		credits = append(credits, Credit{PersonID: int(c.ID), Name: c.Name, Role: c.Character})
+	}
+	for _, job := range creditedJobs {
+		for _, c := range crew {
+			if c.Job == job {
+				credits = append(credits, Credit{PersonID: int(c.ID), Name: c.Name, Role: c.Job})
+			}
+		}
+	}
+	return credits
+}
+
+// Person's details and films from TMDB.
+type Person struct {
+	ID        int
+	Name      string
+	Biography string
+	Born      string // birthday (YYYY-MM-DD)
+	Died      string // deathday (YYYY-MM-DD), empty if alive
+	KnownFor  string // department the person is known for (e.g., "Directing")
+	Films     []PersonFilm
+}
+
+// Film in a person's filmography.
+type PersonFilm struct {
+	tmdb.MovieResult
+	Roles []string // jobs and characters played in the film
+}
+
+// Queries TMDB for the person with the given id, along with their film
+// credits. Films are sorted by release date (unreleased films last).
+func TMDBPerson(id int) (*Person, error) {
+	if TMDBClient == nil {
+		return nil, ErrNoAPI
+	}
+	if err := checkOnline(); err != nil {
+		return nil, err
+	}
+	details, err := TMDBClient.GetPersonDetails(id, localeOptions(map[string]string{"append_to_response": "movie_credits"}, false))
+	if err != nil {
+		return nil, fmt.Errorf("%w, person with id %d, %w", ErrFailedTMDBLookup, id, err)
+	}
+	p := &Person{
+		ID:        int(details.ID),
+		Name:      details.Name,
+		Biography: details.Biography,
+		Born:      details.Birthday,
+		Died:      details.Deathday,
+		KnownFor:  details.KnownForDepartment,
+	}
+	if details.PersonMovieCreditsAppend == nil || details.MovieCredits == nil {
+		return p, nil
+	}
+	films := make(map[int64]*PersonFilm)
+	addFilm := func(r tmdb.MovieResult, role string) {
+		if r.Adult {
+			return
+		}
+		pf, ok := films[r.ID]
+		if !ok {
+			pf = &PersonFilm{MovieResult: r}
+			films[r.ID] = pf
+		}
+		if role != "" && !slices.Contains(pf.Roles, role) {
+			pf.Roles = append(pf.Roles, role)
+		}
+	}
+	for _, c := range details.MovieCredits.Cast {
+		addFilm(tmdb.MovieResult{
+			ID: c.ID, Title: c.Title, OriginalTitle: c.OriginalTitle, ReleaseDate: c.ReleaseDate,
+			Overview: c.Overview, PosterPath: c.PosterPath, Adult: c.Adult, VoteMetrics: c.VoteMetrics,
+		}, c.Character)
+	}
+	for _, c := range details.MovieCredits.Crew {
+		addFilm(tmdb.MovieResult{
+			ID: c.ID, Title: c.Title, OriginalTitle: c.OriginalTitle, ReleaseDate: c.ReleaseDate,
+			Overview: c.Overview, PosterPath: c.PosterPath, Adult: c.Adult, VoteMetrics: c.VoteMetrics,
+		}, c.Job)
+	}
+	for _, pf := range films {
+		p.Films = append(p.Films, *pf)
+	}
+	slices.SortFunc(p.Films, func(a, b PersonFilm) int {
+		return compareReleaseDates(a.ReleaseDate, b.ReleaseDate, a.Title, b.Title)
+	})
+	return p, nil
+}
+
+// Matches TMDB results to the user's films on Letterboxd by TMDB id (if known)
+// or by title and year (Letterboxd gets both from TMDB, using the English or
+// original title).
+type FilmMatcher struct {
+	byTMDB  map[int]Film
+	byTitle map[string]Film
+}
+
+// Makes matcher for films in the film store, the watchlist, and watched films.
+func (app *Application) NewFilmMatcher() *FilmMatcher {
+	return newFilmMatcher(&app.FilmStore, app.Watchlist, app.WatchedFilms)
+}
+
+func newFilmMatcher(store *FilmStore, sets ...FilmsSet) *FilmMatcher {
+	m := &FilmMatcher{byTMDB: store.TMDBIndex(), byTitle: make(map[string]Film)}
+	for _, set := range sets {
+		for _, f := range set {
+			m.byTitle[titleKey(f.Title, f.Year)] = *f
+		}
+	}
+	for _, f := range store.films() {
+		m.byTitle[titleKey(f.Title, f.Year)] = f
+	}
+	return m
+}
+
+func titleKey(title string, year uint) string {
+	return fmt.Sprintf("%s (%d)", strings.ToLower(strings.TrimSpace(title)), year)
+}
+
+// Finds user's film for the TMDB result.
+func (m *FilmMatcher) Match(r tmdb.MovieResult) (Film, bool) {
+	if f, ok := m.byTMDB[int(r.ID)]; ok {
+		return f, true
+	}
+	year, err := ReleaseYear(r)
+	if err != nil || year == 0 {
+		return Film{}, false
+	}
+	for _, title := range []string{r.Title, r.OriginalTitle} { // title may be localized (see config)
+		if f, ok := m.byTitle[titleKey(title, uint(year))]; ok {
+			return f, true
+		}
+	}
+	return Film{}, false
+}
+
+// Number of released films in results, and how many of them the user has
+// watched.
+func (app *Application) WatchedProgress(results []tmdb.MovieResult, m *FilmMatcher) (watched, released int) {
+	now := time.Now()
+	for _, r := range results {
+		date, err := time.Parse("2006-01-02", r.ReleaseDate)
+		if err != nil || date.After(now) {
+			continue
+		}
+		released++
+		if f, ok := m.Match(r); ok && app.WatchedFilms.InSet(&f) {
+			watched++
+		}
+	}
+	return watched, released
+}
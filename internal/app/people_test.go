@@ -0,0 +1,95 @@
+package app
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+func TestCredits(t *testing.T) {
+	var credits tmdb.MovieCredits
+	err := json.Unmarshal([]byte(`{
+		"cast": [{"id": 2, "name": "Björk", "character": "Selma"}, {"id": 3, "name": "Catherine Deneuve", "character": "Kathy"}],
+		"crew": [
+			{"id": 4, "name": "Robby Müller", "job": "Director of Photography"},
+			{"id": 1, "name": "Lars von Trier", "job": "Screenplay"},
+			{"id": 5, "name": "Grip", "job": "Grip"},
+			{"id": 1, "name": "Lars von Trier", "job": "Director"}
+		]
+	}`), &credits)
+	if err != nil {
+		t.Fatalf("could not unmarshal credits, %s", err)
+	}
+	fr := FilmRecord{Details: &tmdb.MovieDetails{MovieCreditsAppend: &tmdb.MovieCreditsAppend{}}}
+	fr.Details.Credits.MovieCredits = &credits
+	want := []Credit{
+		{PersonID: 1, Name: "Lars von Trier", Role: "Director"},
+		{PersonID: 2, Name: "Björk", Role: "Selma"},
+		{PersonID: 3, Name: "Catherine Deneuve", Role: "Kathy"},
+		{PersonID: 1, Name: "Lars von Trier", Role: "Screenplay"},
+		{PersonID: 4, Name: "Robby Müller", Role: "Director of Photography"},
+	}
+	if got := fr.Credits(); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+	if got := (&FilmRecord{}).Credits(); got != nil {
+		t.Errorf("expected no credits without details, got %+v", got)
+	}
+}
+
+func TestFilmMatcher(t *testing.T) {
+	watched := Film{LBxdID: 1, Title: "Dancer in the Dark", Year: 2000}
+	listed := Film{LBxdID: 2, Title: "Dogville", Year: 2003}
+	app := &Application{
+		WatchedFilms: FilmsSet{watched.LBxdID: &watched},
+		Watchlist:    FilmsSet{},
+		FilmStore: FilmStore{Films: map[int]*FilmRecord{
+			listed.LBxdID: {Film: listed, TMDBID: 553},
+		}},
+	}
+	m := app.NewFilmMatcher()
+	testCases := []struct {
+		name   string
+		result tmdb.MovieResult
+		want   Film
+		wantOk bool
+	}{
+		{name: "by tmdb id", result: tmdb.MovieResult{ID: 553, Title: "Dogville (2003)"}, want: listed, wantOk: true},
+		{name: "by title and year", result: tmdb.MovieResult{ID: 16, Title: "dancer in the dark", ReleaseDate: "2000-05-17"}, want: watched, wantOk: true},
+		{name: "by original title", result: tmdb.MovieResult{ID: 16, Title: "Dancer in the Dark (fr)", OriginalTitle: "Dancer in the Dark", ReleaseDate: "2000-05-17"}, want: watched, wantOk: true},
+		{name: "wrong year", result: tmdb.MovieResult{ID: 16, Title: "Dancer in the Dark", ReleaseDate: "2001-05-17"}},
+		{name: "no release date", result: tmdb.MovieResult{ID: 16, Title: "Dancer in the Dark"}},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := m.Match(test.result)
+			if ok != test.wantOk {
+				t.Fatalf("want ok %t, got %t", test.wantOk, ok)
+			}
+			if got != test.want {
+				t.Errorf("want %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestWatchedProgress(t *testing.T) {
+	watched := Film{LBxdID: 1, Title: "Dancer in the Dark", Year: 2000}
+	app := &Application{
+		WatchedFilms: FilmsSet{watched.LBxdID: &watched},
+		Watchlist:    FilmsSet{},
+		FilmStore:    FilmStore{Films: make(map[int]*FilmRecord)},
+	}
+	results := []tmdb.MovieResult{
+		{ID: 16, Title: "Dancer in the Dark", ReleaseDate: "2000-05-17"},
+		{ID: 553, Title: "Dogville", ReleaseDate: "2003-05-19"},
+		{ID: 1, Title: "Untitled Project"},
+		{ID: 2, Title: "Far Future", ReleaseDate: "2999-01-01"},
+	}
+	watchedN, released := app.WatchedProgress(results, app.NewFilmMatcher())
+	if watchedN != 1 || released != 2 {
+		t.Errorf("want 1 of 2 watched, got %d of %d", watchedN, released)
+	}
+}
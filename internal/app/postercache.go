@@ -0,0 +1,71 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/jpeg" // tmdb posters are jpegs
+	_ "image/png"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/adrg/xdg"
+)
+
+const PosterThumbnailSize = "w154" // tmdb image size used for posters shown in nw
+
+// Base url of TMDB images (followed by size and image path).
+var tmdbImageUrl = "https://image.tmdb.org/t/p/"
+
+// Small version of the film's poster, read from the local cache if it has
+// been fetched before (so it works offline) or downloaded from TMDB and cached
+// otherwise.
+func PosterThumbnail(fr FilmRecord) (image.Image, error) {
+	if fr.Details == nil || fr.Details.PosterPath == "" {
+		return nil, fmt.Errorf("%w for film %s", ErrMissingPosterPath, fr.Title)
+	}
+	cached := filepath.Join(posterCacheDir(), PosterThumbnailSize+"_"+path.Base(fr.Details.PosterPath))
+	content, err := os.ReadFile(cached)
+	if err != nil {
+		if content, err = fetchImage(tmdbImageUrl + PosterThumbnailSize + fr.Details.PosterPath); err != nil {
+			return nil, fmt.Errorf("%w for film %s, %w", ErrRetreivingPoster, fr.Title, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(cached), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(cached, content, 0o644); err != nil {
+			return nil, err
+		}
+	}
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("%w for film %s, %w", ErrRetreivingPoster, fr.Title, err)
+	}
+	return img, nil
+}
+
+func fetchImage(url string) ([]byte, error) {
+	if err := checkOnline(); err != nil {
+		return nil, err
+	}
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status code %d != %d", resp.StatusCode, http.StatusOK)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Directory posters shown in nw are cached in.
+func posterCacheDir() string {
+	if Config.Directories.Cache != "" {
+		return filepath.Join(Config.Directories.Cache, "posters")
+	}
+	return filepath.Join(xdg.CacheHome, "nw", "posters")
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+func TestPosterThumbnail(t *testing.T) {
+	resetOffline(t)
+	prevUrl, prevDirs := tmdbImageUrl, Config.Directories
+	t.Cleanup(func() { tmdbImageUrl, Config.Directories = prevUrl, prevDirs })
+	Config.Directories.Cache = t.TempDir()
+
+	var poster bytes.Buffer
+	img := image.NewRGBA(image.Rect(0, 0, 2, 3))
+	img.Set(1, 2, color.RGBA{R: 255, A: 255})
+	if err := png.Encode(&poster, img); err != nil {
+		t.Fatalf("could not encode test poster, %s", err)
+	}
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/"+PosterThumbnailSize+"/poster.png" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(poster.Bytes())
+	}))
+	t.Cleanup(server.Close)
+	tmdbImageUrl = server.URL + "/"
+
+	fr := FilmRecord{Film: Film{Title: "Test"}, Details: &tmdb.MovieDetails{PosterPath: "/poster.png"}}
+	got, err := PosterThumbnail(fr)
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	if got.Bounds() != img.Bounds() {
+		t.Errorf("want bounds %v, got %v", img.Bounds(), got.Bounds())
+	}
+	ForceOffline() // cached poster should not need the network
+	if _, err := PosterThumbnail(fr); err != nil || requests != 1 {
+		t.Errorf("expected poster to be read from cache, got error %v after %d requests", err, requests)
+	}
+	fr.Details.PosterPath = "/other.png"
+	if _, err := PosterThumbnail(fr); !errors.Is(err, ErrOffline) {
+		t.Errorf("expected error %v for uncached poster, got %v", ErrOffline, err)
+	}
+	fr.Details.PosterPath = ""
+	if _, err := PosterThumbnail(fr); !errors.Is(err, ErrMissingPosterPath) {
+		t.Errorf("expected error %v, got %v", ErrMissingPosterPath, err)
+	}
+}
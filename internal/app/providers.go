@@ -0,0 +1,76 @@
+package app
+
+import (
+	"slices"
+	"strings"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+// Where a film can be watched in the configured region (names of streaming
+// services, etc.).
+type WatchProviders struct {
+	Region   string   // region code (e.g., "US")
+	Link     string   // TMDB page listing the providers
+	Flatrate []string // subscription streaming services
+	Rent     []string
+	Buy      []string
+}
+
+// Checks if film is streaming on any of the user's services (see config).
+func (wp WatchProviders) OnMyServices() bool {
+	return slices.ContainsFunc(wp.Flatrate, IsMyService)
+}
+
+// Checks if provider is one of the streaming services the user subscribes to.
+func IsMyService(provider string) bool {
+	return slices.ContainsFunc(Config.TMDB.Services, func(s string) bool { return strings.EqualFold(s, provider) })
+}
+
+// Watch providers for the configured region. Returns false if no region is
+// configured or there is no data for the region (e.g., the details were saved
+// before the region was changed).
+func (fr *FilmRecord) WatchProviders() (WatchProviders, bool) {
+	return watchProviders(fr.Details, Config.TMDB.Region)
+}
+
+func watchProviders(details *tmdb.MovieDetails, region string) (WatchProviders, bool) {
+	if region == "" || details == nil || details.MovieWatchProvidersAppend == nil || details.WatchProviders == nil {
+		return WatchProviders{}, false
+	}
+	res, ok := details.WatchProviders.Results[strings.ToUpper(region)]
+	if !ok {
+		return WatchProviders{}, false
+	}
+	return WatchProviders{
+		Region:   strings.ToUpper(region),
+		Link:     res.Link,
+		Flatrate: providerNames(res.Flatrate),
+		Rent:     providerNames(res.Rent),
+		Buy:      providerNames(res.Buy),
+	}, true
+}
+
+func providerNames(providers *[]tmdb.WatchProvider) []string {
+	if providers == nil {
+		return nil
+	}
+	names := make([]string, 0, len(*providers))
+	for _, p := range *providers {
+		names = append(names, p.ProviderName)
+	}
+	return names
+}
+
+// Drops watch providers for regions other than the configured one, since
+// details are saved and TMDB returns providers for every region.
+func trimWatchProviders(details *tmdb.MovieDetails, region string) {
+	if details.MovieWatchProvidersAppend == nil || details.WatchProviders == nil {
+		return
+	}
+	res, ok := details.WatchProviders.Results[strings.ToUpper(region)]
+	details.WatchProviders.Results = make(map[string]tmdb.WatchProviderResult, 1)
+	if ok {
+		details.WatchProviders.Results[strings.ToUpper(region)] = res
+	}
+}
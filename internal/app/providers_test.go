@@ -0,0 +1,97 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+func testProviderDetails() *tmdb.MovieDetails {
+	netflix := []tmdb.WatchProvider{{ProviderName: "Netflix"}, {ProviderName: "Mubi"}}
+	apple := []tmdb.WatchProvider{{ProviderName: "Apple TV"}}
+	return &tmdb.MovieDetails{
+		MovieWatchProvidersAppend: &tmdb.MovieWatchProvidersAppend{
+			WatchProviders: &tmdb.WatchProviderResults{Results: map[string]tmdb.WatchProviderResult{
+				"US": {Link: "https://www.themoviedb.org/movie/16/watch?locale=US", Flatrate: &netflix, Buy: &apple},
+				"GB": {Rent: &apple},
+			}},
+		},
+	}
+}
+
+func TestWatchProviders(t *testing.T) {
+	testCases := []struct {
+		name    string
+		details *tmdb.MovieDetails
+		region  string
+		want    WatchProviders
+		wantOk  bool
+	}{
+		{
+			name:    "region",
+			details: testProviderDetails(),
+			region:  "us",
+			want: WatchProviders{
+				Region:   "US",
+				Link:     "https://www.themoviedb.org/movie/16/watch?locale=US",
+				Flatrate: []string{"Netflix", "Mubi"},
+				Buy:      []string{"Apple TV"},
+			},
+			wantOk: true,
+		},
+		{name: "no region configured", details: testProviderDetails()},
+		{name: "no data for region", details: testProviderDetails(), region: "FR"},
+		{name: "no providers fetched", details: &tmdb.MovieDetails{}, region: "US"},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := watchProviders(test.details, test.region)
+			if ok != test.wantOk {
+				t.Fatalf("want ok %t, got %t", test.wantOk, ok)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestTrimWatchProviders(t *testing.T) {
+	details := testProviderDetails()
+	trimWatchProviders(details, "gb")
+	if results := details.WatchProviders.Results; len(results) != 1 || results["GB"].Rent == nil {
+		t.Errorf("expected only GB providers to be kept, got %v", results)
+	}
+	trimWatchProviders(details, "FR")
+	if results := details.WatchProviders.Results; len(results) != 0 {
+		t.Errorf("expected no providers to be kept, got %v", results)
+	}
+}
+
+func TestQueueFiltersMyServices(t *testing.T) {
+	prev := Config.TMDB
+	t.Cleanup(func() { Config.TMDB = prev })
+	filters := QueueFilters{MyServices: true}
+	film := Film{LBxdID: 1}
+	testCases := []struct {
+		name     string
+		region   string
+		services []string
+		details  *tmdb.MovieDetails
+		want     bool
+	}{
+		{name: "on service", region: "US", services: []string{"mubi"}, details: testProviderDetails(), want: true},
+		{name: "not on service", region: "US", services: []string{"Hulu"}, details: testProviderDetails()},
+		{name: "only to rent", region: "GB", services: []string{"Apple TV"}, details: testProviderDetails()},
+		{name: "no details", region: "US", services: []string{"Hulu"}, want: true},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			Config.TMDB = tmdbConfig{Region: test.region, Services: test.services}
+			if got := filters.match(film, test.details); got != test.want {
+				t.Errorf("want %t, got %t", test.want, got)
+			}
+		})
+	}
+}
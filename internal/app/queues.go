@@ -0,0 +1,237 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"slices"
+	"strings"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+var ErrInvalidQueue = errors.New("invalid queue")
+
+// Where a named queue picks its films from.
+type SourceKind int
+
+const (
+	SourceWatchlist SourceKind = iota // user's watchlist
+	SourceLists                       // one or more tracked lists
+)
+
+type QueueSource struct {
+	Kind  SourceKind
+	Lists []string // urls of tracked lists (SourceLists only)
+}
+
+func (qs QueueSource) String() string {
+	if qs.Kind == SourceLists {
+		return strings.Join(qs.Lists, ", ")
+	}
+	return "watchlist"
+}
+
+func (qs QueueSource) equal(other QueueSource) bool {
+	return qs.Kind == other.Kind && slices.Equal(qs.Lists, other.Lists)
+}
+
+// Parses queue source from config. Source is "watchlist" or the url of a
+// list; any extra lists are added to the source.
+func parseQueueSource(source string, lists []string) (QueueSource, error) {
+	if source == "" && len(lists) == 0 || strings.EqualFold(source, "watchlist") {
+		if len(lists) > 0 {
+			return QueueSource{}, fmt.Errorf("%w, lists cannot be combined with watchlist", ErrInvalidQueue)
+		}
+		return QueueSource{Kind: SourceWatchlist}, nil
+	}
+	if source != "" {
+		lists = append([]string{source}, lists...)
+	}
+	qs := QueueSource{Kind: SourceLists}
+	for _, l := range lists {
+		u, err := url.Parse(l)
+		if err == nil {
+			_, err = listKind(u)
+		}
+		if err != nil {
+			return QueueSource{}, fmt.Errorf("%w, unknown source %q, %w", ErrInvalidQueue, l, err)
+		}
+		if !slices.Contains(qs.Lists, l) {
+			qs.Lists = append(qs.Lists, l)
+		}
+	}
+	return qs, nil
+}
+
+// Rules films must pass to be picked for a queue. Zero values mean no limit.
+// Runtime and genre rules are skipped for films without details from TMDB.
+type QueueFilters struct {
+	MinRuntime int      // minimum runtime in minutes
+	MaxRuntime int      // maximum runtime in minutes
+	MinYear    uint     // earliest release year
+	MaxYear    uint     // latest release year
+	Genres     []string // film must have one of these genres (case insensitive)
+	MyServices bool     // film must be streaming on one of the user's services (see config)
+}
+
+// Checks if film (with details if available) passes the filters.
+func (qf *QueueFilters) match(film Film, details *tmdb.MovieDetails) bool {
+	if qf.MinYear != 0 && film.Year < qf.MinYear || qf.MaxYear != 0 && film.Year > qf.MaxYear {
+		return false
+	}
+	if details == nil {
+		return true
+	}
+	if qf.MinRuntime != 0 && details.Runtime < qf.MinRuntime || qf.MaxRuntime != 0 && details.Runtime > qf.MaxRuntime {
+		return false
+	}
+	if qf.MyServices {
+		if wp, ok := watchProviders(details, Config.TMDB.Region); !ok || !wp.OnMyServices() {
+			return false
+		}
+	}
+	return len(qf.Genres) == 0 || slices.ContainsFunc(details.Genres, func(g tmdb.Genre) bool {
+		return slices.ContainsFunc(qf.Genres, func(name string) bool { return strings.EqualFold(name, g.Name) })
+	})
+}
+
+func (qf QueueFilters) equal(other QueueFilters) bool {
+	return qf.MinRuntime == other.MinRuntime && qf.MaxRuntime == other.MaxRuntime &&
+		qf.MinYear == other.MinYear && qf.MaxYear == other.MaxYear && slices.Equal(qf.Genres, other.Genres) &&
+		qf.MyServices == other.MyServices
+}
+
+// Named Next Watch queue with its own source and filters (e.g., a "long
+// films" queue picking films over three hours from the watchlist).
+type Queue struct {
+	Name      string       // name of queue
+	Source    QueueSource  // where films are picked from
+	Filters   QueueFilters // rules films must pass to be picked
+	NextWatch NextWatch    // queue of films picked from source
+}
+
+// Returns named queue, or nil if it doesn't exist.
+func (app *Application) Queue(name string) *Queue {
+	for _, q := range app.Queues {
+		if q.Name == name {
+			return q
+		}
+	}
+	return nil
+}
+
+// Makes named queue, replacing any existing queue with the same name. Lists
+// in the source must be tracked (they are never tracked automatically, so
+// lists the user stopped tracking stay untracked).
+func (app *Application) MakeQueue(name string, source QueueSource, filters QueueFilters) error {
+	q := &Queue{Name: name, Source: source, Filters: filters}
+	pool, err := app.queuePool(q)
+	if err != nil {
+		return err
+	}
+	if q.NextWatch, err = app.makeFilteredNextWatch(pool, &q.Filters); err != nil {
+		return fmt.Errorf("could not make queue %s, %w", name, err)
+	}
+	app.RemoveQueue(name)
+	app.Queues = append(app.Queues, q)
+	return nil
+}
+
+// Removes named queue.
+func (app *Application) RemoveQueue(name string) {
+	app.Queues = slices.DeleteFunc(app.Queues, func(q *Queue) bool { return q.Name == name })
+}
+
+// Films the queue picks from (the union of films on the source lists).
+func (app *Application) queuePool(q *Queue) (FilmsSet, error) {
+	if q.Source.Kind == SourceWatchlist {
+		return app.Watchlist, nil
+	}
+	pool := make(FilmsSet)
+	for _, l := range q.Source.Lists {
+		fl, ok := app.TrackedLists[l]
+		if !ok {
+			return nil, fmt.Errorf("%w, source of queue %s, %s", ErrListNotTracked, q.Name, l)
+		}
+		for _, f := range fl.Films {
+			pool[f.LBxdID] = f
+		}
+	}
+	return pool, nil
+}
+
+// Refreshes queues picking films from list, so films added to the list can be
+// picked and removed films are dropped.
+func (app *Application) refreshListQueues(listUrl string) {
+	for _, q := range app.Queues {
+		if !slices.Contains(q.Source.Lists, listUrl) {
+			continue
+		}
+		log.Printf("refreshing queue %s from list %s", q.Name, listUrl)
+		if err := q.rehydrate(app); err != nil {
+			continue
+		}
+		if err := q.NextWatch.UpdateWatched(); err != nil {
+			log.Printf("could not refresh queue %s, %s", q.Name, err)
+		}
+	}
+}
+
+// Updates all queues, making queues defined in the config that don't exist
+// yet (or have changed) and removing queues that are no longer in the config.
+func (app *Application) updateQueues() error {
+	var lastErr error
+	configured := make(map[string]bool, len(Config.Queues))
+	for _, qc := range Config.Queues {
+		configured[qc.Name] = true
+		source, err := parseQueueSource(qc.Source, qc.Lists)
+		if err != nil {
+			lastErr = fmt.Errorf("queue %s in config, %w", qc.Name, err)
+			continue
+		}
+		filters := qc.filters()
+		if q := app.Queue(qc.Name); q != nil && q.Source.equal(source) && q.Filters.equal(filters) {
+			if _, err := app.queuePool(q); err == nil {
+				continue
+			}
+		}
+		if err := app.MakeQueue(qc.Name, source, filters); err != nil {
+			if isNetworkError(err) {
+				return err
+			}
+			lastErr = err
+		}
+	}
+	app.Queues = slices.DeleteFunc(app.Queues, func(q *Queue) bool {
+		if !configured[q.Name] {
+			return true
+		}
+		if err := q.rehydrate(app); err != nil {
+			lastErr = err
+		}
+		if err := q.NextWatch.UpdateWatched(); err != nil { // queue is kept (with empty spots) so its films aren't lost
+			lastErr = fmt.Errorf("could not fill queue %s, %w", q.Name, err)
+		}
+		return false
+	})
+	return lastErr
+}
+
+// Post JSON unmarshal setup (also picks up changes to the source's films).
+func (q *Queue) rehydrate(app *Application) error {
+	if q.NextWatch.lastUpdated == nil {
+		q.NextWatch.makeLastUpdate()
+	}
+	q.NextWatch.watchedFilms = app.WatchedFilms
+	q.NextWatch.store = &app.FilmStore
+	q.NextWatch.filters = &q.Filters
+	pool, err := app.queuePool(q)
+	if err != nil {
+		log.Printf("could not get films for queue %s, %s", q.Name, err)
+		pool = make(FilmsSet)
+	}
+	q.NextWatch.watchlist = pool
+	return err
+}
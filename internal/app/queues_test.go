@@ -0,0 +1,207 @@
+package app
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+func TestQueueFiltersMatch(t *testing.T) {
+	film := Film{LBxdID: 1, Year: 1979}
+	details := &tmdb.MovieDetails{Runtime: 117, Genres: []tmdb.Genre{{Name: "Horror"}, {Name: "Science Fiction"}}}
+	testCases := []struct {
+		name    string
+		filters QueueFilters
+		details *tmdb.MovieDetails
+		want    bool
+	}{
+		{name: "no filters", want: true},
+		{name: "in year range", filters: QueueFilters{MinYear: 1970, MaxYear: 1979}, details: details, want: true},
+		{name: "too old", filters: QueueFilters{MinYear: 1980}, details: details},
+		{name: "too new", filters: QueueFilters{MaxYear: 1978}},
+		{name: "too short", filters: QueueFilters{MinRuntime: 150}, details: details},
+		{name: "too long", filters: QueueFilters{MaxRuntime: 100}, details: details},
+		{name: "genre", filters: QueueFilters{Genres: []string{"comedy", "horror"}}, details: details, want: true},
+		{name: "wrong genre", filters: QueueFilters{Genres: []string{"comedy"}}, details: details},
+		{name: "no details", filters: QueueFilters{MaxRuntime: 100, Genres: []string{"comedy"}}, want: true},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.filters.match(film, test.details); got != test.want {
+				t.Errorf("want %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestParseQueueSource(t *testing.T) {
+	horror, comedy := "https://letterboxd.com/user/list/horror/", "https://letterboxd.com/user/list/comedy/"
+	testCases := []struct {
+		name    string
+		source  string
+		lists   []string
+		want    QueueSource
+		wantErr error
+	}{
+		{name: "default", want: QueueSource{Kind: SourceWatchlist}},
+		{name: "watchlist", source: "Watchlist", want: QueueSource{Kind: SourceWatchlist}},
+		{name: "list", source: horror, want: QueueSource{Kind: SourceLists, Lists: []string{horror}}},
+		{
+			name:  "several lists",
+			lists: []string{horror, comedy, horror},
+			want:  QueueSource{Kind: SourceLists, Lists: []string{horror, comedy}},
+		},
+		{
+			name:   "source and lists",
+			source: comedy,
+			lists:  []string{horror},
+			want:   QueueSource{Kind: SourceLists, Lists: []string{comedy, horror}},
+		},
+		{name: "watchlist and lists", source: "watchlist", lists: []string{horror}, wantErr: ErrInvalidQueue},
+		{name: "not letterboxd", source: "https://example.com/user/list/horror/", wantErr: ErrInvalidQueue},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseQueueSource(test.source, test.lists)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("expected error %v, got %v", test.wantErr, err)
+			}
+			if !got.equal(test.want) {
+				t.Errorf("want %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestMakeQueue(t *testing.T) {
+	resetOffline(t)
+	listUrl := "https://letterboxd.com/user/list/films/"
+	films := make(FilmsSet)
+	for id := 1; id <= 100; id++ {
+		films[id] = &Film{LBxdID: id, Year: uint(1900 + id)}
+	}
+	otherUrl := "https://letterboxd.com/user/list/other/"
+	list, other := &FilmList{Url: listUrl}, &FilmList{Url: otherUrl}
+	for id := 51; id <= 100; id++ {
+		list.Films = append(list.Films, films[id])
+	}
+	for id := 41; id <= 50; id++ {
+		other.Films = append(other.Films, films[id])
+	}
+	testCases := []struct {
+		name     string
+		source   QueueSource
+		filters  QueueFilters
+		wantFrom int // lowest film id that may be in queue
+		wantErr  error
+	}{
+		{name: "watchlist", source: QueueSource{Kind: SourceWatchlist}, wantFrom: 1},
+		{name: "filtered watchlist", source: QueueSource{Kind: SourceWatchlist}, filters: QueueFilters{MinYear: 1961}, wantFrom: 61},
+		{name: "tracked list", source: QueueSource{Kind: SourceLists, Lists: []string{listUrl}}, wantFrom: 51},
+		{name: "tracked lists", source: QueueSource{Kind: SourceLists, Lists: []string{listUrl, otherUrl}}, wantFrom: 41},
+		{
+			name:    "too few films after filtering",
+			source:  QueueSource{Kind: SourceLists, Lists: []string{listUrl}},
+			filters: QueueFilters{MinYear: 1990},
+			wantErr: ErrNotEnoughFilms,
+		},
+		{
+			name:    "untracked list",
+			source:  QueueSource{Kind: SourceLists, Lists: []string{"https://letterboxd.com/user/list/untracked/"}},
+			wantErr: ErrListNotTracked,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			app := Application{
+				Watchlist:    films,
+				WatchedFilms: make(FilmsSet),
+				TrackedLists: map[string]*FilmList{listUrl: list, otherUrl: other},
+			}
+			seedFilmStore(t, &app.FilmStore, films)
+			err := app.MakeQueue(test.name, test.source, test.filters)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("expected error %v, got %v", test.wantErr, err)
+			}
+			if err != nil {
+				if len(app.Queues) != 0 {
+					t.Errorf("expected no queue to be added, got %d", len(app.Queues))
+				}
+				return
+			}
+			q := app.Queue(test.name)
+			if q == nil {
+				t.Fatalf("queue %s not found", test.name)
+			}
+			for i, j := range q.NextWatch.Positions() {
+				if id := q.NextWatch.Stacks[i][j].LBxdID; id < test.wantFrom {
+					t.Errorf("film %d should not be in queue", id)
+				}
+			}
+			app.RemoveQueue(test.name)
+			if app.Queue(test.name) != nil {
+				t.Errorf("expected queue to be removed")
+			}
+		})
+	}
+}
+
+func TestRefreshListQueues(t *testing.T) {
+	resetOffline(t)
+	listUrl := "https://letterboxd.com/user/list/films/"
+	films := make(FilmsSet)
+	list := &FilmList{Url: listUrl}
+	for id := 1; id <= NumberOfStacks*StackSize+2; id++ {
+		films[id] = &Film{LBxdID: id}
+		list.Films = append(list.Films, films[id])
+	}
+	app := Application{WatchedFilms: make(FilmsSet), TrackedLists: map[string]*FilmList{listUrl: list}}
+	seedFilmStore(t, &app.FilmStore, films)
+	if err := app.MakeQueue("list", QueueSource{Kind: SourceLists, Lists: []string{listUrl}}, QueueFilters{}); err != nil {
+		t.Fatalf("could not make queue, %s", err)
+	}
+	nw := &app.Queue("list").NextWatch
+	removed := nw.Stacks[0][0]
+	list.Films = slices.DeleteFunc(list.Films, func(f *Film) bool { return f == removed })
+	app.refreshListQueues(listUrl)
+	if nw.ContainsFilm(*removed) {
+		t.Errorf("film removed from list is still in queue")
+	}
+	if !nw.Full() {
+		t.Errorf("expected queue to be refilled from list")
+	}
+}
+
+func TestUpdateQueuesKeepsUnfilledQueue(t *testing.T) {
+	resetOffline(t)
+	prev := Config.Queues
+	t.Cleanup(func() { Config.Queues = prev })
+	listUrl := "https://letterboxd.com/user/list/films/"
+	Config.Queues = []queueConfig{{Name: "list", Source: listUrl}}
+	films := make(FilmsSet)
+	list := &FilmList{Url: listUrl}
+	for id := 1; id <= NumberOfStacks*StackSize+1; id++ {
+		films[id] = &Film{LBxdID: id}
+		list.Films = append(list.Films, films[id])
+	}
+	app := Application{WatchedFilms: make(FilmsSet), TrackedLists: map[string]*FilmList{listUrl: list}}
+	seedFilmStore(t, &app.FilmStore, films)
+	if err := app.updateQueues(); err != nil {
+		t.Fatalf("could not make queue, %s", err)
+	}
+	watched := list.Films[0]
+	app.WatchedFilms[watched.LBxdID] = watched
+	err := app.updateQueues()
+	if !errors.Is(err, ErrNotEnoughFilms) {
+		t.Fatalf("expected error %v, got %v", ErrNotEnoughFilms, err)
+	}
+	q := app.Queue("list")
+	if q == nil {
+		t.Fatalf("expected queue to be kept")
+	}
+	if q.NextWatch.ContainsFilm(*watched) || len(q.NextWatch.Films()) != NumberOfStacks*StackSize {
+		t.Errorf("expected queue to keep its unwatched films, got %d films", len(q.NextWatch.Films()))
+	}
+}
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gocolly/colly"
+)
+
+// Get the user's rating of a film. Returns false if the user has not rated,
+// liked, or rewatched the film.
+func (app *Application) Rating(film Film) (FilmRating, bool) {
+	r, ok := app.Ratings[film.LBxdID]
+	if !ok {
+		return FilmRating{}, false
+	}
+	return *r, true
+}
+
+// Set rating and like status scraped from the user's films pages, where nil
+// means the film is neither rated nor liked. Rewatch status is kept since it
+// is only known from the diary.
+func (app *Application) updateRating(id int, r *FilmRating) {
+	cur, ok := app.Ratings[id]
+	if !ok {
+		if r == nil {
+			return
+		}
+		cur = &FilmRating{}
+	}
+	cur.Stars, cur.Liked = 0, false
+	if r != nil {
+		cur.Stars, cur.Liked = r.Stars, r.Liked
+	}
+	app.setRating(id, cur)
+}
+
+// Merge a diary entry into the user's rating of a film. Ratings and likes
+// only replace existing ones if set, since diary entries may leave them out.
+func (app *Application) updateRatingFromDiary(id int, r FilmRating) {
+	cur, ok := app.Ratings[id]
+	if !ok {
+		cur = &FilmRating{}
+	}
+	if r.Stars != 0 {
+		cur.Stars = r.Stars
+	}
+	cur.Liked = cur.Liked || r.Liked
+	cur.Rewatched = cur.Rewatched || r.Rewatched
+	app.setRating(id, cur)
+}
+
+func (app *Application) setRating(id int, r *FilmRating) {
+	if app.Ratings == nil {
+		app.Ratings = make(map[int]*FilmRating)
+	}
+	if *r == (FilmRating{}) {
+		delete(app.Ratings, id)
+		return
+	}
+	app.Ratings[id] = r
+}
+
+// Updates ratings, likes, and rewatches from the first page of the user's
+// diary.
+func (app *Application) updateDiaryPage() error {
+	log.Print("updating ratings from diary")
+	ratings, err := ScrapeDiaryPage(app.Username)
+	if err != nil {
+		return err
+	}
+	for id, r := range ratings {
+		if _, ok := app.WatchedFilms[id]; ok {
+			app.updateRatingFromDiary(id, *r)
+		}
+	}
+	return nil
+}
+
+// Scrapes the user's ratings, likes, and rewatches from the most recent page
+// of their diary. Ratings are indexed by letterboxd film id.
+func ScrapeDiaryPage(username string) (map[int]*FilmRating, error) {
+	if err := checkOnline(); err != nil {
+		return nil, err
+	}
+	diaryUrl, err := url.JoinPath(LetterboxdUrl, username, "films", "diary")
+	if err != nil {
+		return nil, fmt.Errorf("problem joining url parts, %w", err)
+	}
+	ratings := make(map[int]*FilmRating)
+	c := colly.NewCollector()
+	status := attachScrapeLogger(c, "diary")
+	c.OnHTML("tr.diary-entry-row", func(h *colly.HTMLElement) {
+		id, err := strconv.Atoi(h.ChildAttr("div.react-component", "data-film-id"))
+		if err != nil {
+			log.Printf("failed to parse film id from diary entry, %s", err)
+			return
+		}
+		r, ok := ratings[id]
+		if !ok {
+			r = &FilmRating{}
+			ratings[id] = r
+		}
+		h.ForEach("td.td-rating .rating, td.col-rating .rating", func(_ int, h *colly.HTMLElement) {
+			if stars := ratingFromClass(h.Attr("class")); stars != 0 && r.Stars == 0 {
+				r.Stars = stars // entries are newest first, keep most recent rating
+			}
+		})
+		h.ForEach("td.td-like .icon-liked, td.col-like .icon-liked", func(_ int, _ *colly.HTMLElement) {
+			r.Liked = true
+		})
+		h.ForEach("td.td-rewatch, td.col-rewatch", func(_ int, h *colly.HTMLElement) {
+			if !strings.Contains(h.Attr("class"), "icon-status-off") {
+				r.Rewatched = true
+			}
+		})
+	})
+	if err := c.Visit(diaryUrl + "/"); err != nil {
+		return nil, fmt.Errorf("problem trying to visit url %s, %w", diaryUrl, status.wrap(err))
+	}
+	return ratings, nil
+}
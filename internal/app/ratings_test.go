@@ -0,0 +1,97 @@
+package app
+
+import "testing"
+
+func TestFilmRatingStarString(t *testing.T) {
+	testCases := []struct {
+		stars uint
+		want  string
+	}{
+		{0, ""},
+		{1, "½"},
+		{7, "★★★½"},
+		{10, "★★★★★"},
+	}
+	for _, test := range testCases {
+		if got := (FilmRating{Stars: test.stars}).StarString(); got != test.want {
+			t.Errorf("StarString(%d) = %q, want %q", test.stars, got, test.want)
+		}
+	}
+}
+
+func TestRatingFromClass(t *testing.T) {
+	testCases := []struct {
+		class string
+		want  uint
+	}{
+		{"rating -micro -darker rated-8", 8},
+		{"rating rated-1", 1},
+		{"rating", 0},
+		{"rating rated-11", 0},
+	}
+	for _, test := range testCases {
+		if got := ratingFromClass(test.class); got != test.want {
+			t.Errorf("ratingFromClass(%q) = %d, want %d", test.class, got, test.want)
+		}
+	}
+}
+
+func TestUpdateRating(t *testing.T) {
+	testCases := []struct {
+		name    string
+		initial *FilmRating
+		films   *FilmRating // from films page
+		diary   *FilmRating // from diary (applied after)
+		want    FilmRating
+		wantOk  bool
+	}{
+		{
+			name:   "sets rating from films page",
+			films:  &FilmRating{Stars: 7, Liked: true},
+			want:   FilmRating{Stars: 7, Liked: true},
+			wantOk: true,
+		},
+		{
+			name:    "films page keeps rewatch",
+			initial: &FilmRating{Stars: 4, Rewatched: true},
+			films:   &FilmRating{Stars: 6},
+			want:    FilmRating{Stars: 6, Rewatched: true},
+			wantOk:  true,
+		},
+		{
+			name:    "clears unrated film",
+			initial: &FilmRating{Stars: 4, Liked: true},
+			films:   nil,
+			want:    FilmRating{},
+			wantOk:  false,
+		},
+		{
+			name:   "diary without rating keeps rating",
+			films:  &FilmRating{Stars: 9},
+			diary:  &FilmRating{Rewatched: true},
+			want:   FilmRating{Stars: 9, Rewatched: true},
+			wantOk: true,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			film := Film{LBxdID: 1}
+			app := &Application{}
+			if test.initial != nil {
+				r := *test.initial
+				app.Ratings = map[int]*FilmRating{film.LBxdID: &r}
+			}
+			app.updateRating(film.LBxdID, test.films)
+			if test.diary != nil {
+				app.updateRatingFromDiary(film.LBxdID, *test.diary)
+			}
+			got, ok := app.Rating(film)
+			if ok != test.wantOk {
+				t.Fatalf("expected ok=%t, got %t", test.wantOk, ok)
+			}
+			if got != test.want {
+				t.Fatalf("want=%+v != got=%+v", test.want, got)
+			}
+		})
+	}
+}
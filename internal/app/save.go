@@ -13,8 +13,9 @@ import (
 )
 
 const (
-	LatestSaveVersion  = 0
-	userDataExpireTime = time.Hour * 24
+	LatestSaveVersion         = 3
+	defaultUserDataExpireTime = time.Hour * 24
+	watchedFullSyncTime       = time.Hour * 24 * 7 // full sync catches films removed from watched
 
 	lastUserFile = "lastusername.txt"
 	saveExt      = ".json"
@@ -23,7 +24,7 @@ const (
 // ----- Save functionality
 
 type Save struct {
-	Application
+	*Application
 	Version int // save version, if format changes are made this will be incremented
 }
 
@@ -35,7 +36,9 @@ func (app *Application) Save() error {
 			return err
 		}
 	}
-	bytes, err := json.Marshal(Save{Application: *app, Version: LatestSaveVersion})
+	app.FilmStore.mu.Lock()
+	bytes, err := json.Marshal(Save{Application: app, Version: LatestSaveVersion})
+	app.FilmStore.mu.Unlock()
 	if err != nil {
 		return err
 	}
@@ -55,11 +58,14 @@ func Load(username string) (*Application, error) {
 		if err != nil {
 			return nil, err
 		}
-		var save Save
+		save := Save{Application: &Application{}}
 		if err := json.Unmarshal(bytes, &save); err != nil {
 			return nil, err
 		}
-		app := &save.Application
+		app := save.Application
+		if save.Version < LatestSaveVersion {
+			log.Printf("save is from version %d (latest is %d), newer fields start out empty", save.Version, LatestSaveVersion)
+		}
 		app.rehydrate()
 		return app, nil
 	} else if errors.Is(err, os.ErrNotExist) {
@@ -113,11 +119,18 @@ func (app *Application) rehydrate() {
 	}
 	for _, list := range app.TrackedLists {
 		list.watched = app.WatchedFilms
+		list.store = &app.FilmStore
 	}
 	app.NWQueue.makeLastUpdate()
 	app.NWQueue.watchedFilms = app.WatchedFilms
 	app.NWQueue.watchlist = app.Watchlist
 	app.NWQueue.store = &app.FilmStore
+	for _, g := range app.Groups {
+		g.rehydrate(app)
+	}
+	for _, q := range app.Queues {
+		_ = q.rehydrate(app) // logged; queue is left empty until its source is tracked again
+	}
 }
 
 func getAPIKey() string {
@@ -143,21 +156,62 @@ func CreateApp(username string) (*Application, error) {
 // Updates all of the user's watchlist, watched films, and lists
 //
 // Argument "check," when true, checks whether previous data has expired---if
-// it has not, nothing is done.
+// it has not, nothing is done. When false (i.e., a manual update), the network
+// is retried if offline mode was entered automatically.
+//
+// If Letterboxd or TMDB cannot be reached, the application switches to offline
+// mode and keeps using saved data; an error is only returned if there is no
+// saved data to use.
 func (app *Application) UpdateUserData(check bool) error {
-	if check && time.Since(app.UserDataChecked) < userDataExpireTime {
+	if check && time.Since(app.UserDataChecked) < userDataExpireTime() {
+		if !Offline() {
+			app.FilmStore.retrieveDeferred()
+		}
 		return nil
 	}
+	if !check {
+		goOnline()
+	}
+	if Offline() {
+		return app.useSavedData()
+	}
+	if err := app.updateUserData(); err != nil {
+		if !isNetworkError(err) {
+			return err
+		}
+		goOffline(err)
+		return app.useSavedData()
+	}
+	app.FilmStore.retrieveDeferred()
+	return app.Save()
+}
+
+func (app *Application) updateUserData() error {
 	log.Print("updating user data...")
 	if err := app.updateListHeaders(); err != nil {
 		return err
 	}
+	if err := app.updateMembers(); err != nil {
+		if isNetworkError(err) {
+			return err
+		}
+		log.Printf("could not update followed members, %s", err)
+	}
 	if err := app.updateWatchlist(); err != nil {
 		return err
 	}
+	if err := app.updateDiary(); err != nil { // the watched films sync covers the diary anyway
+		log.Printf("could not update diary, %s", err)
+	}
 	if err := app.updateWatchedFilms(); err != nil {
 		return err
 	}
+	if err := app.updateDiaryPage(); err != nil {
+		if isNetworkError(err) {
+			return err
+		}
+		log.Printf("could not update ratings from diary, %s", err)
+	}
 	if app.NWQueue.Stacks != nil {
 		app.NWQueue.watchlist = app.Watchlist
 		app.NWQueue.watchedFilms = app.WatchedFilms
@@ -170,11 +224,40 @@ func (app *Application) UpdateUserData(check bool) error {
 			return err
 		}
 	}
+	if err := app.updateGroups(); err != nil {
+		if isNetworkError(err) {
+			return err
+		}
+		log.Printf("could not update groups, %s", err)
+	}
 	if err := app.updateTrackedLists(false); err != nil {
 		return err
 	}
+	if err := app.updateQueues(); err != nil {
+		if isNetworkError(err) {
+			return err
+		}
+		log.Printf("could not update queues, %s", err)
+		app.updateErrs = append(app.updateErrs, err)
+	}
 	app.UserDataChecked = time.Now()
-	return app.Save()
+	return nil
+}
+
+// Returns problems found while updating user data that did not stop the
+// update, since this was last called (e.g., to show after updating).
+func (app *Application) TakeUpdateErrors() []error {
+	errs := app.updateErrs
+	app.updateErrs = nil
+	return errs
+}
+
+// Time after which user data is considered expired (see config).
+func userDataExpireTime() time.Duration {
+	if hours := Config.Cache.UserDataExpireHours; hours > 0 {
+		return time.Duration(hours) * time.Hour
+	}
+	return defaultUserDataExpireTime
 }
 
 func (app *Application) updateWatchlist() error {
@@ -191,23 +274,70 @@ func (app *Application) updateWatchlist() error {
 	return nil
 }
 
+// Updates watched films. Usually this only walks the user's most recently
+// watched films until it reaches ones that are already known; a full sync is
+// done when there are no watched films yet or the last full sync is older than
+// a week (in order to catch films that were removed).
 func (app *Application) updateWatchedFilms() error {
-	log.Print("updating watched films")
-	watchedFilms, err := retrieveWatchedFilms(app.Username)
+	if app.WatchedFilms == nil || time.Since(app.WatchedFullSync) >= watchedFullSyncTime {
+		return app.syncAllWatchedFilms()
+	}
+	return app.syncRecentWatchedFilms()
+}
+
+func (app *Application) syncAllWatchedFilms() error {
+	log.Print("updating watched films (full sync)")
+	watchedFilms, ratings, err := retrieveWatchedFilms(app.Username)
 	if err != nil {
 		return err
 	}
-	if app.WatchedFilms != nil {
-		app.FilmStore.DeregisterSet(app.WatchedFilms)
+	added, removed := diffFilmSets(app.WatchedFilms, watchedFilms)
+	app.applyWatchedDiff(added, removed)
+	for id := range watchedFilms {
+		app.updateRating(id, ratings[id])
 	}
-	app.FilmStore.RegisterSet(watchedFilms)
-	app.WatchedFilms = watchedFilms
-	for _, v := range app.TrackedLists {
-		v.watched = app.WatchedFilms
+	app.WatchedFullSync = time.Now()
+	return nil
+}
+
+func (app *Application) syncRecentWatchedFilms() error {
+	log.Print("updating watched films (recent)")
+	added, ratings, err := retrieveRecentWatchedFilms(app.Username, app.WatchedFilms)
+	if err != nil {
+		return err
+	}
+	app.applyWatchedDiff(added, nil)
+	for id, r := range ratings {
+		app.updateRating(id, r)
 	}
 	return nil
 }
 
+// Add and remove films from the watched films set, updating film store
+// references. The set is modified in place so lists and the Next Watch queue
+// see the changes.
+func (app *Application) applyWatchedDiff(added, removed []*Film) {
+	if app.WatchedFilms == nil {
+		app.WatchedFilms = make(FilmsSet)
+		for _, v := range app.TrackedLists {
+			v.watched = app.WatchedFilms
+		}
+		app.NWQueue.watchedFilms = app.WatchedFilms
+	}
+	for _, f := range added {
+		app.WatchedFilms[f.LBxdID] = f
+		app.FilmStore.register(*f)
+	}
+	app.applyPendingDiary(added)
+	for _, f := range removed {
+		delete(app.WatchedFilms, f.LBxdID)
+		delete(app.Ratings, f.LBxdID)
+		delete(app.WatchDates, f.LBxdID)
+		app.FilmStore.deregister(*f)
+	}
+	log.Printf("watched films updated, %d added, %d removed", len(added), len(removed))
+}
+
 func (app *Application) updateListHeaders() error {
 	log.Print("updating user's lists")
 	headers, err := ScrapeUserLists(app.Username)
@@ -219,13 +349,25 @@ func (app *Application) updateListHeaders() error {
 }
 
 // Updates the data in tracked film lists. Skips lists where the next up film
-// is unwatched (in order avoid excessive overall update times). This behavior
-// can be overridden with forceAll.
+// is unwatched and that have not changed on Letterboxd (in order avoid
+// excessive overall update times). This behavior can be overridden with
+// forceAll.
 func (app *Application) updateTrackedLists(forceAll bool) error {
 	var lastErr error
 	for _, fl := range app.TrackedLists {
-		if fl.NextFilm != nil && app.WatchedFilms.InSet(fl.NextFilm) || forceAll {
-			if err := app.RefreshList(fl); err != nil {
+		if fl.Kind == KindLocal {
+			continue
+		}
+		refresh := forceAll || fl.NextFilm != nil && app.WatchedFilms.InSet(fl.NextFilm)
+		if !refresh {
+			changed, err := app.listChanged(fl)
+			if err != nil {
+				log.Printf("failed checking list %s for changes, %s", fl.Name, err)
+			}
+			refresh = changed
+		}
+		if refresh {
+			if _, err := app.RefreshList(fl); err != nil {
 				lastErr = err
 				log.Printf("failed refreshing list %s, %s", fl.Name, err)
 			}
@@ -250,20 +392,67 @@ func retrieveWatchlist(username string) (map[int]*Film, error) {
 	return filmListToMap(watchlist), nil
 }
 
-// Retrieved watched films from letterboxd
-func retrieveWatchedFilms(username string) (map[int]*Film, error) {
+// Retrieved watched films (and the user's ratings of them) from letterboxd
+func retrieveWatchedFilms(username string) (map[int]*Film, map[int]*FilmRating, error) {
 	fUrl, err := url.JoinPath(LetterboxdUrl, username, "films")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	films, err := ScrapeFilmList(fUrl)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	} else if films.Name != "" {
-		return nil, fmt.Errorf("films list had unexpected name %s", films.Name)
+		return nil, nil, fmt.Errorf("films list had unexpected name %s", films.Name)
 	}
 	films.Name = "Watched"
-	return filmListToMap(films), nil
+	return filmListToMap(films), films.ratings, nil
+}
+
+// Retrieve films most recently added to the user's watched films that are not
+// in known, along with the user's ratings of the films that were scraped.
+// Pages are scraped newest first until a page contains only known films.
+func retrieveRecentWatchedFilms(username string, known FilmsSet) ([]*Film, map[int]*FilmRating, error) {
+	fUrl, err := url.JoinPath(LetterboxdUrl, username, "films", "by", "date")
+	if err != nil {
+		return nil, nil, err
+	}
+	films, err := scrapeFilmList(fUrl, func(page []*Film) bool {
+		for _, f := range page {
+			if !known.InSet(f) {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	} else if films.Name != "" {
+		return nil, nil, fmt.Errorf("films list had unexpected name %s", films.Name)
+	}
+	added := make([]*Film, 0)
+	ratings := make(map[int]*FilmRating, len(films.Films))
+	for _, f := range films.Films {
+		if !known.InSet(f) {
+			added = append(added, f)
+		}
+		ratings[f.LBxdID] = films.ratings[f.LBxdID]
+	}
+	return added, ratings, nil
+}
+
+// Films in cur but not in prev (added) and in prev but not in cur (removed).
+func diffFilmSets(prev, cur FilmsSet) (added, removed []*Film) {
+	for id, f := range cur {
+		if _, ok := prev[id]; !ok {
+			added = append(added, f)
+		}
+	}
+	for id, f := range prev {
+		if _, ok := cur[id]; !ok {
+			removed = append(removed, f)
+		}
+	}
+	return added, removed
 }
 
 // Convert a FilmList struct to a map from letterboxd ids to films
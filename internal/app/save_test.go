@@ -12,15 +12,15 @@ import (
 func TestApplicationSave(t *testing.T) {
 	testCases := []struct {
 		name string
-		app  Application
+		app  *Application
 	}{
 		{
 			name: "writes save file",
-			app: Application{
+			app: &Application{
 				Username: "alice",
 				FilmStore: FilmStore{Films: map[int]*FilmRecord{
 					1: {Film: Film{LBxdID: 1, Title: "Stored", Url: "https://example.com/film"}, NRefs: 1, Checked: time.Now()},
-				}},
+				}, Deferred: map[int]Film{2: {LBxdID: 2, Title: "Deferred"}}},
 			},
 		},
 	}
@@ -53,6 +53,9 @@ func TestApplicationSave(t *testing.T) {
 			if stored.Title != "Stored" {
 				t.Fatalf("expected stored title, got %s", stored.Title)
 			}
+			if deferred, ok := saved.FilmStore.Deferred[2]; !ok || deferred.Title != "Deferred" {
+				t.Fatalf("expected deferred film to be stored, got %v", saved.FilmStore.Deferred)
+			}
 		})
 	}
 }
@@ -69,7 +72,7 @@ func TestLoadReturnsSavedData(t *testing.T) {
 			user: "bob",
 			content: Save{
 				Version: LatestSaveVersion,
-				Application: Application{
+				Application: &Application{
 					Username: "bob",
 					FilmStore: FilmStore{Films: map[int]*FilmRecord{
 						7: {Film: Film{LBxdID: 7, Title: "Loaded"}, NRefs: 2},
@@ -215,3 +218,69 @@ func TestUpdateTrackedListsNilNextFilm(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyWatchedDiff(t *testing.T) {
+	testCases := []struct {
+		name        string
+		watched     FilmsSet
+		added       []*Film
+		removed     []*Film
+		wantWatched []int
+		wantRefs    map[int]uint
+	}{
+		{
+			name:        "adds films to empty set",
+			watched:     nil,
+			added:       []*Film{{LBxdID: 1}, {LBxdID: 2}},
+			wantWatched: []int{1, 2},
+			wantRefs:    map[int]uint{1: 1, 2: 1},
+		},
+		{
+			name:        "adds and removes films",
+			watched:     FilmsSet{1: {LBxdID: 1}, 2: {LBxdID: 2}},
+			added:       []*Film{{LBxdID: 3}},
+			removed:     []*Film{{LBxdID: 1}},
+			wantWatched: []int{2, 3},
+			wantRefs:    map[int]uint{1: 0, 2: 1, 3: 1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := &Application{
+				WatchedFilms: tc.watched,
+				TrackedLists: map[string]*FilmList{"list": {}},
+				FilmStore:    FilmStore{Films: map[int]*FilmRecord{}},
+			}
+			app.FilmStore.RegisterSet(tc.watched)
+			app.applyWatchedDiff(tc.added, tc.removed)
+			if len(app.WatchedFilms) != len(tc.wantWatched) {
+				t.Fatalf("expected %d watched films, got %d", len(tc.wantWatched), len(app.WatchedFilms))
+			}
+			for _, id := range tc.wantWatched {
+				if _, ok := app.WatchedFilms[id]; !ok {
+					t.Fatalf("expected film %d to be watched", id)
+				}
+			}
+			for id, refs := range tc.wantRefs {
+				if got := app.FilmStore.Films[id].NRefs; got != refs {
+					t.Fatalf("expected %d refs for film %d, got %d", refs, id, got)
+				}
+			}
+			if tc.watched == nil && (app.TrackedLists["list"].watched == nil || app.NWQueue.watchedFilms == nil) {
+				t.Fatalf("lists and queue should share watched films set")
+			}
+		})
+	}
+}
+
+func TestDiffFilmSets(t *testing.T) {
+	prev := FilmsSet{1: {LBxdID: 1}, 2: {LBxdID: 2}}
+	cur := FilmsSet{2: {LBxdID: 2}, 3: {LBxdID: 3}}
+	added, removed := diffFilmSets(prev, cur)
+	if len(added) != 1 || added[0].LBxdID != 3 {
+		t.Fatalf("expected film 3 to be added, got %v", added)
+	}
+	if len(removed) != 1 || removed[0].LBxdID != 1 {
+		t.Fatalf("expected film 1 to be removed, got %v", removed)
+	}
+}
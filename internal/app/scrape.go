@@ -14,26 +14,36 @@ import (
 	"github.com/imroc/req/v3"
 )
 
-const LetterboxdUrl = "https://letterboxd.com"
+const (
+	LetterboxdUrl  = "https://letterboxd.com"
+	listCountSlack = 0.9 // fraction of a list's advertised films that must be scraped
+)
 
 var (
 	ErrBadScrape  error = errors.New("bad scrape")
 	ErrInvalidUrl error = errors.New("invalid url")
 	ErrNotAFilm   error = errors.New("not a film")
 
-	titleYearRegex = regexp.MustCompile(`^(.+?)\s+\((\d{4})\)$`)
+	titleYearRegex     = regexp.MustCompile(`^(.+?)\s+\((\d{4})\)$`)
+	listCountRegex     = regexp.MustCompile(`(?i)\ba list of ([\d,]+) films?\b`)
+	averageRatingRegex = regexp.MustCompile(`^\s*(\d+(?:\.\d+)?) out of 5\b`)
 )
 
 func ScrapeUserLists(username string) ([]*FilmList, error) {
+	if err := checkOnline(); err != nil {
+		return nil, err
+	}
 	listPageUrl, err := url.JoinPath(LetterboxdUrl, username, "lists")
 	if err != nil {
 		return nil, fmt.Errorf("problem joining url parts, %w", err)
 	}
 	usersListUrls := []*FilmList{}
 	c := colly.NewCollector()
-	attachScrapeLogger(c, "user lists")
+	status := attachScrapeLogger(c, "user lists")
+	found := 0 // list summaries on the page, parsed or not
 	c.OnHTML("div.body", func(h *colly.HTMLElement) {
-		fl := &FilmList{}
+		found++
+		fl := &FilmList{Owner: username}
 		h.ForEach("h2.name.prettify a[href]", func(_ int, link *colly.HTMLElement) {
 			if listUrl := link.Request.AbsoluteURL(link.Attr("href")); strings.Contains(listUrl, "/list/") {
 				fl.Name = strings.TrimSpace(link.Text)
@@ -60,15 +70,19 @@ func ScrapeUserLists(username string) ([]*FilmList, error) {
 		}
 		nextURL := h.Request.AbsoluteURL(h.Attr("href"))
 		if err := c.Visit(nextURL); err != nil && !errors.Is(err, colly.ErrAlreadyVisited) {
-			paginationErr = fmt.Errorf("paginate user lists: %w", err)
+			paginationErr = fmt.Errorf("paginate user lists: %w", status.wrap(err))
 		}
 	})
 	if err := c.Visit(listPageUrl); err != nil {
-		return nil, fmt.Errorf("problem trying to visit url %s, %w", listPageUrl, err)
+		return nil, fmt.Errorf("problem trying to visit url %s, %w", listPageUrl, status.wrap(err))
 	}
 	if paginationErr != nil {
 		return nil, paginationErr
 	}
+	if found > 0 && len(usersListUrls) == 0 {
+		return nil, &ScrapeError{Kind: ErrMarkupChanged, Url: listPageUrl,
+			Err: fmt.Errorf("found %d lists but could not parse any", found)}
+	}
 	return usersListUrls, nil
 }
 
@@ -76,6 +90,15 @@ func ScrapeUserLists(username string) ([]*FilmList, error) {
 //
 // The list name may be empty if it is not listed on the webpage (e.g., a watchlist).
 func ScrapeFilmList(rawURL string) (fl FilmList, err error) {
+	return scrapeFilmList(rawURL, nil)
+}
+
+// Scraps film list page by page. If stop is not nil, it is called with the
+// films from each page and pagination ends early once it returns true.
+func scrapeFilmList(rawURL string, stop func(page []*Film) bool) (fl FilmList, err error) {
+	if err = checkOnline(); err != nil {
+		return
+	}
 	url, err := url.Parse(rawURL)
 	if err != nil {
 		err = fmt.Errorf("%w, %w", ErrInvalidUrl, err)
@@ -86,7 +109,13 @@ func ScrapeFilmList(rawURL string) (fl FilmList, err error) {
 	}
 	fl.Url = rawURL
 	c := colly.NewCollector()
-	attachScrapeLogger(c, rawURL)
+	status := attachScrapeLogger(c, rawURL)
+	check := listCheck{advertised: -1}
+	c.OnHTML(`meta[name="description"]`, func(h *colly.HTMLElement) {
+		if check.advertised < 0 {
+			check.advertised = advertisedFilmCount(h.Attr("content"))
+		}
+	})
 	c.OnHTML("h1.title-1.prettify", func(h *colly.HTMLElement) {
 		fl.Name = strings.TrimSpace(h.Text)
 	})
@@ -96,7 +125,31 @@ func ScrapeFilmList(rawURL string) (fl FilmList, err error) {
 		}
 		fl.Desc = parseDescription(h, "p")
 	})
+	fl.Owner = listOwner(url)
+	c.OnHTML("ul.tags", func(h *colly.HTMLElement) {
+		if fl.Tags != nil { // only keep tags from first page
+			return
+		}
+		fl.Tags = make([]string, 0)
+		h.ForEach("a", func(_ int, h *colly.HTMLElement) {
+			if tag := strings.TrimSpace(h.Text); tag != "" {
+				fl.Tags = append(fl.Tags, tag)
+			}
+		})
+	})
+	c.OnHTML(".list-date time[datetime]", func(h *colly.HTMLElement) {
+		if t, err := time.Parse(time.RFC3339, h.Attr("datetime")); err == nil && t.After(fl.Updated) {
+			fl.Updated = t // list shows published and updated dates, keep the latest
+		}
+	})
+	c.OnHTML(`a[href$="/likes/"]`, func(h *colly.HTMLElement) {
+		if n, ok := parseCount(h.Text); ok && fl.Likes == 0 {
+			fl.Likes = n
+		}
+	})
 	posterScrapper := func(h *colly.HTMLElement) {
+		check.containers++
+		check.items += len(h.DOM.Find("li").Nodes)
 		h.ForEach("div.react-component", func(_ int, h *colly.HTMLElement) {
 			if fUrl := h.Request.AbsoluteURL(h.Attr("data-target-link")); strings.Contains(fUrl, "/film/") {
 				f := Film{Url: fUrl}
@@ -117,6 +170,18 @@ func ScrapeFilmList(rawURL string) (fl FilmList, err error) {
 				}
 			}
 		})
+		h.ForEach("li", func(_ int, h *colly.HTMLElement) {
+			id, err := strconv.Atoi(h.ChildAttr("div.react-component", "data-film-id"))
+			if err != nil {
+				return
+			}
+			if r, ok := parseViewingData(h); ok {
+				if fl.ratings == nil {
+					fl.ratings = make(map[int]*FilmRating)
+				}
+				fl.ratings[id] = &r
+			}
+		})
 		h.ForEachWithBreak("li.posteritem.numbered-list-item", func(i int, h *colly.HTMLElement) bool {
 			fl.Ordered = true
 			return false
@@ -126,36 +191,193 @@ func ScrapeFilmList(rawURL string) (fl FilmList, err error) {
 	c.OnHTML("ul.poster-grid", posterScrapper)
 	c.OnHTML("div.poster-grid", posterScrapper)
 	var paginationErr error
+	pageStart := 0 // index of first film on current page
 	c.OnHTML(".next", func(h *colly.HTMLElement) {
 		if paginationErr != nil {
 			return
 		}
+		if len(fl.Films) == pageStart {
+			check.emptyPages++
+		}
+		if stop != nil && stop(fl.Films[pageStart:]) {
+			check.partial = true
+			return
+		}
+		pageStart = len(fl.Films)
 		nextURL := h.Request.AbsoluteURL(h.Attr("href"))
 		if err := c.Visit(nextURL); err != nil && !errors.Is(err, colly.ErrAlreadyVisited) {
-			paginationErr = fmt.Errorf("paginate list: %w", err)
+			paginationErr = fmt.Errorf("paginate list: %w", status.wrap(err))
 		}
 	})
 	if err = c.Visit(url.String()); err != nil {
+		err = status.wrap(err)
 		return
 	}
 	if paginationErr != nil {
 		err = paginationErr
 		return
 	}
+	check.parsed = len(fl.Films)
+	if err = check.err(); err != nil {
+		err = &ScrapeError{Kind: ErrMarkupChanged, Url: rawURL, Err: err}
+		return
+	}
 	fl.NumFilms = len(fl.Films)
+	if check.partial {
+		fl.NumFilms = check.advertised
+	}
 	return
 }
 
-func ScrapeFilmID(rawURL string) (id int, err error) {
-	filmUrl, err := url.Parse(rawURL)
+// Scrapes list name and metadata from the first page of the list, without the
+// rest of the films. NumFilms is the number of films the list page advertises
+// (-1 if the page does not say).
+func ScrapeListHeader(rawURL string) (FilmList, error) {
+	return scrapeFilmList(rawURL, func([]*Film) bool { return true })
+}
+
+// Scrapes notes on list entries from the detail view of the list. Notes are
+// indexed by letterboxd film id; entries without notes are left out.
+func ScrapeListNotes(rawURL string) (map[int]string, error) {
+	if err := checkOnline(); err != nil {
+		return nil, err
+	}
+	detailUrl, err := url.JoinPath(rawURL, "detail")
+	if err != nil {
+		return nil, fmt.Errorf("%w, %w", ErrInvalidUrl, err)
+	}
+	notes := make(map[int]string)
+	c := colly.NewCollector()
+	status := attachScrapeLogger(c, detailUrl)
+	c.OnHTML("li.film-detail", func(h *colly.HTMLElement) {
+		id, err := strconv.Atoi(h.ChildAttr("[data-film-id]", "data-film-id"))
+		if err != nil {
+			return
+		}
+		if note := parseDescription(h, ".film-detail-content .body-text p"); note != "" {
+			notes[id] = note
+		}
+	})
+	var paginationErr error
+	c.OnHTML(".next", func(h *colly.HTMLElement) {
+		if paginationErr != nil {
+			return
+		}
+		nextURL := h.Request.AbsoluteURL(h.Attr("href"))
+		if err := c.Visit(nextURL); err != nil && !errors.Is(err, colly.ErrAlreadyVisited) {
+			paginationErr = fmt.Errorf("paginate list notes: %w", status.wrap(err))
+		}
+	})
+	if err := c.Visit(detailUrl + "/"); err != nil {
+		return nil, fmt.Errorf("problem trying to visit url %s, %w", detailUrl, status.wrap(err))
+	}
+	if paginationErr != nil {
+		return nil, paginationErr
+	}
+	return notes, nil
+}
+
+// Owner of the list at url (e.g., "oscars" for letterboxd.com/oscars/list/...).
+// Returns an empty string if url is not a list.
+func listOwner(u *url.URL) string {
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) >= 3 && parts[1] == "list" {
+		return parts[0]
+	}
+	return ""
+}
+
+// Parses count shown on Letterboxd (e.g., "1,234 likes" or "12K likes").
+func parseCount(text string) (int, bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	num, mult := strings.ReplaceAll(fields[0], ",", ""), 1.0
+	switch {
+	case strings.HasSuffix(num, "K"):
+		num, mult = strings.TrimSuffix(num, "K"), 1e3
+	case strings.HasSuffix(num, "M"):
+		num, mult = strings.TrimSuffix(num, "M"), 1e6
+	}
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return int(n * mult), true
+}
+
+// Sanity checks for a scraped film list, so that changes to Letterboxd's
+// markup produce an error instead of a list silently missing films.
+type listCheck struct {
+	containers int  // poster lists/grids found
+	items      int  // poster list items found
+	parsed     int  // films successfully parsed
+	emptyPages int  // pages followed by another page that had no films
+	advertised int  // film count given in the page description (-1 if unknown)
+	partial    bool // pagination was stopped early
+}
+
+func (lc listCheck) err() error {
+	switch {
+	case lc.emptyPages > 0:
+		return fmt.Errorf("%d paginated pages had no films", lc.emptyPages)
+	case lc.items > 0 && lc.parsed == 0:
+		return fmt.Errorf("found %d posters but could not parse any films", lc.items)
+	case lc.containers == 0 && lc.advertised > 0:
+		return fmt.Errorf("page lists %d films but no poster list was found", lc.advertised)
+	case !lc.partial && lc.advertised > 0 && float64(lc.parsed) < listCountSlack*float64(lc.advertised):
+		return fmt.Errorf("page lists %d films but only %d were scraped", lc.advertised, lc.parsed)
+	}
+	return nil
+}
+
+// Parses number of films from a list page description (e.g., "A list of 10
+// films compiled on Letterboxd, ..."). Returns -1 if there is no count.
+func advertisedFilmCount(desc string) int {
+	matches := listCountRegex.FindStringSubmatch(desc)
+	if len(matches) != 2 {
+		return -1
+	}
+	n, err := strconv.Atoi(strings.ReplaceAll(matches[1], ",", ""))
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+func ScrapeFilmID(rawURL string) (int, error) {
+	page, err := ScrapeFilmPage(rawURL)
 	if err != nil {
 		return -1, err
+	}
+	return page.TMDBID, nil
+}
+
+// Details scraped from a Letterboxd film page.
+type FilmPage struct {
+	TMDBID        int     // tmdb id number
+	AverageRating float64 // average rating out of 5 (0 if film has too few ratings)
+}
+
+// Scrapes the TMDB id and average rating from a film's Letterboxd page.
+func ScrapeFilmPage(rawURL string) (page FilmPage, err error) {
+	if err = checkOnline(); err != nil {
+		return page, err
+	}
+	filmUrl, err := url.Parse(rawURL)
+	if err != nil {
+		return page, err
 	} else if filmUrl.Hostname() != "letterboxd.com" {
-		return -1, fmt.Errorf("%w, %s is not a letterboxd.com url", ErrInvalidUrl, filmUrl)
+		return page, fmt.Errorf("%w, %s is not a letterboxd.com url", ErrInvalidUrl, filmUrl)
 	}
 	c := colly.NewCollector()
-	attachScrapeLogger(c, rawURL)
+	status := attachScrapeLogger(c, rawURL)
 	var scrapingErr error
+	id := 0
+	c.OnHTML(`meta[name="twitter:data2"]`, func(h *colly.HTMLElement) {
+		page.AverageRating = parseAverageRating(h.Attr("content"))
+	})
 	c.OnHTML("a.micro-button.track-event", func(h *colly.HTMLElement) {
 		if h.Text == "TMDB" {
 			tmdbURL, err := filmUrl.Parse(h.Attr("href"))
@@ -179,6 +401,7 @@ func ScrapeFilmID(rawURL string) (id int, err error) {
 		}
 	})
 	if err = c.Visit(filmUrl.String()); err != nil {
+		err = status.wrap(err)
 		return
 	}
 	if scrapingErr != nil {
@@ -186,11 +409,85 @@ func ScrapeFilmID(rawURL string) (id int, err error) {
 		return
 	}
 	if id == 0 {
-		err = fmt.Errorf("%w, did not find TMDB id when scraping %s", ErrInvalidUrl, rawURL)
+		err = &ScrapeError{Kind: ErrMarkupChanged, Url: rawURL, Err: errors.New("did not find TMDB id")}
 	}
+	page.TMDBID = id
 	return
 }
 
+// Parses average rating from the film page's meta tag (e.g., "3.86 out of
+// 5"). Returns 0 if it cannot be parsed.
+func parseAverageRating(content string) float64 {
+	matches := averageRatingRegex.FindStringSubmatch(content)
+	if len(matches) != 2 {
+		return 0
+	}
+	rating, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return 0
+	}
+	return rating
+}
+
+// Scrapes the Letterboxd film matching the TMDB id (Letterboxd redirects
+// /tmdb/<id>/ to the film's page).
+func ScrapeFilmFromTMDB(tmdbID int) (film Film, err error) {
+	if err = checkOnline(); err != nil {
+		return Film{}, err
+	}
+	rawURL := fmt.Sprintf("%s/tmdb/%d/", LetterboxdUrl, tmdbID)
+	c := colly.NewCollector()
+	status := attachScrapeLogger(c, rawURL)
+	c.OnHTML(`meta[property="og:url"]`, func(h *colly.HTMLElement) {
+		film.Url = h.Attr("content")
+	})
+	c.OnHTML(`meta[property="og:title"]`, func(h *colly.HTMLElement) {
+		if matches := titleYearRegex.FindStringSubmatch(h.Attr("content")); len(matches) == 3 {
+			film.Title = strings.TrimSpace(matches[1])
+			if year, err := strconv.Atoi(matches[2]); err == nil {
+				film.Year = uint(year)
+			}
+		}
+	})
+	c.OnHTML("[data-film-id]", func(h *colly.HTMLElement) {
+		if id, err := strconv.Atoi(h.Attr("data-film-id")); err == nil && film.LBxdID == 0 {
+			film.LBxdID = id // first poster on the page is the film's own
+		}
+	})
+	if err = c.Visit(rawURL); err != nil {
+		return Film{}, status.wrap(err)
+	}
+	if film.LBxdID == 0 || film.Title == "" || !strings.Contains(film.Url, "/film/") {
+		return Film{}, &ScrapeError{Kind: ErrMarkupChanged, Url: rawURL, Err: fmt.Errorf("could not parse film %+v", film)}
+	}
+	return film, nil
+}
+
+// Parses user's rating and like from the viewing data below a poster (only
+// shown on a user's films pages).
+func parseViewingData(h *colly.HTMLElement) (r FilmRating, ok bool) {
+	h.ForEach(".poster-viewingdata .rating", func(_ int, h *colly.HTMLElement) {
+		r.Stars = ratingFromClass(h.Attr("class"))
+	})
+	h.ForEachWithBreak(".poster-viewingdata .like", func(_ int, _ *colly.HTMLElement) bool {
+		r.Liked = true
+		return false
+	})
+	return r, r.Stars != 0 || r.Liked
+}
+
+// Parses half star rating from class attribute (e.g., "rating -micro rated-7").
+func ratingFromClass(class string) uint {
+	for _, c := range strings.Fields(class) {
+		if n, ok := strings.CutPrefix(c, "rated-"); ok {
+			if stars, err := strconv.Atoi(n); err == nil && stars > 0 && stars <= 10 {
+				return uint(stars)
+			}
+		}
+	}
+	return 0
+}
+
 func parseDescription(h *colly.HTMLElement, selector string) string {
 	var builder strings.Builder
 	first := true
@@ -209,10 +506,13 @@ func parseDescription(h *colly.HTMLElement, selector string) string {
 	return builder.String()
 }
 
-func attachScrapeLogger(c *colly.Collector, label string) {
+// Sets up collector transport (with retries), rate limiting, and error
+// logging. The returned status records errors so that they can be reported as
+// a ScrapeError.
+func attachScrapeLogger(c *colly.Collector, label string) *scrapeStatus {
 	// Use req/v3 to impersonate Chrome's TLS fingerprint (JA3)
 	client := req.C().ImpersonateChrome()
-	c.WithTransport(client.Transport)
+	c.WithTransport(&retryTransport{base: client.Transport})
 
 	c.UserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/128.0.0.0 Safari/537.36"
 	// Keep per-collector limit as a fallback/jitter
@@ -222,20 +522,15 @@ func attachScrapeLogger(c *colly.Collector, label string) {
 		RandomDelay: 100 * time.Millisecond,
 	})
 
+	status := &scrapeStatus{}
 	c.OnError(func(resp *colly.Response, err error) {
-		status := 0
-		url := label
-		if resp != nil {
-			status = resp.StatusCode
-			if resp.Request != nil && resp.Request.URL != nil {
-				url = resp.Request.URL.String()
-			}
-		}
-		log.Printf("scrape error [%s] status=%d url=%s err=%v", label, status, url, err)
+		status.record(resp, label, err)
+		log.Printf("scrape error [%s] %s", label, status.last)
 	})
 	c.OnResponse(func(resp *colly.Response) {
 		if resp.StatusCode >= 400 {
 			log.Printf("scrape response [%s] status=%d url=%s", label, resp.StatusCode, resp.Request.URL)
 		}
 	})
+	return status
 }
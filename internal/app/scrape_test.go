@@ -2,6 +2,7 @@ package app
 
 import (
 	"errors"
+	"net/url"
 	"reflect"
 	"testing"
 )
@@ -117,3 +118,108 @@ func BenchmarkScrapeFilmID(b *testing.B) {
 		}
 	}
 }
+
+func TestListCheck(t *testing.T) {
+	testCases := []struct {
+		name    string
+		check   listCheck
+		wantErr bool
+	}{
+		{name: "complete list", check: listCheck{containers: 1, items: 10, parsed: 10, advertised: 10}},
+		{name: "unknown count", check: listCheck{containers: 1, items: 10, parsed: 10, advertised: -1}},
+		{name: "empty list", check: listCheck{advertised: 0}},
+		{name: "few unparsed films", check: listCheck{containers: 1, items: 100, parsed: 98, advertised: 100}},
+		{name: "stopped early", check: listCheck{containers: 1, items: 72, parsed: 72, advertised: 500, partial: true}},
+		{name: "no poster list", check: listCheck{advertised: 10}, wantErr: true},
+		{name: "posters not parsed", check: listCheck{containers: 1, items: 10, advertised: -1}, wantErr: true},
+		{name: "missing films", check: listCheck{containers: 1, items: 50, parsed: 50, advertised: 100}, wantErr: true},
+		{name: "empty page", check: listCheck{containers: 2, items: 72, parsed: 72, emptyPages: 1, advertised: -1}, wantErr: true},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.check.err(); (err != nil) != test.wantErr {
+				t.Errorf("wantErr=%t, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAdvertisedFilmCount(t *testing.T) {
+	testCases := []struct {
+		name string
+		desc string
+		want int
+	}{
+		{name: "list", desc: "A list of 10 films compiled on Letterboxd, including Oppenheimer and Barbie.", want: 10},
+		{name: "thousands", desc: "A list of 1,001 films compiled on Letterboxd.", want: 1001},
+		{name: "single film", desc: "A list of 1 film compiled on Letterboxd.", want: 1},
+		{name: "no count", desc: "Letterboxd is a social platform for sharing your taste in film.", want: -1},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			if got := advertisedFilmCount(test.desc); got != test.want {
+				t.Errorf("want %d, got %d", test.want, got)
+			}
+		})
+	}
+}
+
+func TestListOwner(t *testing.T) {
+	testCases := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://letterboxd.com/oscars/list/the-96th-academy-award-nominees-for-best/", want: "oscars"},
+		{url: "https://letterboxd.com/jsdoublel/watchlist/", want: ""},
+		{url: "https://letterboxd.com/film/barbie/", want: ""},
+	}
+	for _, test := range testCases {
+		t.Run(test.url, func(t *testing.T) {
+			u, err := url.Parse(test.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := listOwner(u); got != test.want {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestParseCount(t *testing.T) {
+	testCases := []struct {
+		text   string
+		want   int
+		wantOk bool
+	}{
+		{text: "12 likes", want: 12, wantOk: true},
+		{text: "1,204 likes", want: 1204, wantOk: true},
+		{text: "3.4K likes", want: 3400, wantOk: true},
+		{text: "1M", want: 1000000, wantOk: true},
+		{text: "likes", wantOk: false},
+		{text: "", wantOk: false},
+	}
+	for _, test := range testCases {
+		t.Run(test.text, func(t *testing.T) {
+			got, ok := parseCount(test.text)
+			if ok != test.wantOk || got != test.want {
+				t.Errorf("want (%d, %t), got (%d, %t)", test.want, test.wantOk, got, ok)
+			}
+		})
+	}
+}
+
+func TestParseAverageRating(t *testing.T) {
+	testCases := map[string]float64{
+		"3.86 out of 5":  3.86,
+		"4 out of 5":     4,
+		"":               0,
+		"no rating":      0,
+		"3.86 out of 10": 0,
+	}
+	for content, want := range testCases {
+		if got := parseAverageRating(content); got != want {
+			t.Errorf("%q: want %v, got %v", content, want, got)
+		}
+	}
+}
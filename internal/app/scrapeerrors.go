@@ -0,0 +1,160 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gocolly/colly"
+)
+
+const (
+	maxScrapeRetries = 3                      // retries for rate limited or server errors
+	retryBaseDelay   = 500 * time.Millisecond // first retry delay, doubled each retry
+	retryMaxDelay    = 30 * time.Second       // upper bound on any single retry delay
+)
+
+// Kinds of scrape failures (see ScrapeError).
+var (
+	ErrRateLimited   = errors.New("rate limited by letterboxd")
+	ErrPageNotFound  = errors.New("letterboxd page not found")
+	ErrPrivate       = errors.New("letterboxd page is private or blocked")
+	ErrMarkupChanged = errors.New("letterboxd page markup has changed")
+	ErrServerError   = errors.New("letterboxd server error")
+	ErrNetwork       = errors.New("could not reach letterboxd")
+	ErrRequestFailed = errors.New("letterboxd request failed")
+)
+
+// Error from scraping a Letterboxd page. Kind is one of the scrape failure
+// kinds (e.g., ErrRateLimited), so errors.Is can be used to check the cause.
+type ScrapeError struct {
+	Kind   error  // kind of failure
+	Url    string // page being scraped
+	Status int    // HTTP status code (zero if there was no response)
+	Err    error  // underlying error
+}
+
+func (e *ScrapeError) Error() string {
+	if e.Status != 0 {
+		return fmt.Sprintf("%s (status %d) at %s, %s", e.Kind, e.Status, e.Url, e.Err)
+	}
+	return fmt.Sprintf("%s at %s, %s", e.Kind, e.Url, e.Err)
+}
+
+func (e *ScrapeError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// Classifies error from a collector request by the response status code. Only
+// transport failures (with no response) count as ErrNetwork, as those are
+// what switch nw to offline mode.
+func newScrapeError(url string, status int, err error) *ScrapeError {
+	se := &ScrapeError{Url: url, Status: status, Err: err}
+	switch {
+	case status == http.StatusTooManyRequests:
+		se.Kind = ErrRateLimited
+	case status == http.StatusNotFound || status == http.StatusGone:
+		se.Kind = ErrPageNotFound
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		se.Kind = ErrPrivate
+	case status >= 200 && status < 400: // page loaded but could not be parsed
+		se.Kind = ErrMarkupChanged
+	case status >= http.StatusInternalServerError:
+		se.Kind = ErrServerError
+	case status == 0 && isTransportError(err):
+		se.Kind = ErrNetwork
+	default: // other statuses, or request rejected by the collector
+		se.Kind = ErrRequestFailed
+	}
+	return se
+}
+
+// Checks if error came from failing to reach the server at all (e.g., DNS
+// lookup or connection failure).
+func isTransportError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// Records the most recent error from a collector so the error returned by
+// Visit can be replaced with a ScrapeError describing its cause.
+type scrapeStatus struct {
+	last *ScrapeError
+}
+
+// Returns a ScrapeError for an error returned from visiting a page with the
+// collector (nil if err is nil).
+func (ss *scrapeStatus) wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+	if ss.last != nil && ss.last.Err == err {
+		return ss.last
+	}
+	var se *ScrapeError
+	if errors.As(err, &se) {
+		return err
+	}
+	return newScrapeError("", 0, err)
+}
+
+func (ss *scrapeStatus) record(resp *colly.Response, label string, err error) {
+	status, url := 0, label
+	if resp != nil {
+		status = resp.StatusCode
+		if resp.Request != nil && resp.Request.URL != nil {
+			url = resp.Request.URL.String()
+		}
+	}
+	ss.last = newScrapeError(url, status, err)
+}
+
+// Retries requests that were rate limited or failed with a server error,
+// backing off exponentially (with jitter) or for as long as the server asks
+// with Retry-After.
+type retryTransport struct {
+	base http.RoundTripper
+}
+
+func (rt *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := rt.base.RoundTrip(req)
+		if err != nil || !retryableStatus(resp.StatusCode) || attempt == maxScrapeRetries {
+			return resp, err
+		}
+		delay := retryDelay(attempt, resp.Header.Get("Retry-After"), time.Now())
+		_ = resp.Body.Close()
+		log.Printf("scrape retry %d/%d status=%d url=%s, waiting %s",
+			attempt+1, maxScrapeRetries, resp.StatusCode, req.URL, delay)
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func retryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// Delay before retrying a request. Honours Retry-After (in seconds or as an
+// HTTP date) if given; otherwise backs off exponentially with jitter.
+func retryDelay(attempt int, retryAfter string, now time.Time) time.Duration {
+	if retryAfter != "" {
+		if secs, err := strconv.Atoi(retryAfter); err == nil && secs >= 0 {
+			return min(time.Duration(secs)*time.Second, retryMaxDelay)
+		}
+		if t, err := http.ParseTime(retryAfter); err == nil {
+			return min(max(t.Sub(now), 0), retryMaxDelay)
+		}
+	}
+	delay := min(retryBaseDelay<<attempt, retryMaxDelay)
+	jitter := time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	return min(delay+jitter, retryMaxDelay)
+}
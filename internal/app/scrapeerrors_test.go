@@ -0,0 +1,126 @@
+package app
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gocolly/colly"
+)
+
+func TestNewScrapeError(t *testing.T) {
+	dnsErr := &url.Error{Op: "Get", URL: "https://letterboxd.com/user/", Err: &net.DNSError{Err: "no such host"}}
+	testCases := []struct {
+		name    string
+		status  int
+		cause   error
+		want    error
+		network bool
+	}{
+		{name: "rate limited", status: http.StatusTooManyRequests, want: ErrRateLimited},
+		{name: "not found", status: http.StatusNotFound, want: ErrPageNotFound},
+		{name: "blocked", status: http.StatusForbidden, want: ErrPrivate},
+		{name: "server error", status: http.StatusBadGateway, want: ErrServerError},
+		{name: "no response", status: 0, cause: dnsErr, want: ErrNetwork, network: true},
+		{name: "rejected by collector", status: 0, cause: colly.ErrForbiddenDomain, want: ErrRequestFailed},
+		{name: "parse failure", status: http.StatusOK, want: ErrMarkupChanged},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			cause := test.cause
+			if cause == nil {
+				cause = errors.New(http.StatusText(test.status))
+			}
+			err := error(newScrapeError("https://letterboxd.com/user/", test.status, cause))
+			if !errors.Is(err, test.want) {
+				t.Fatalf("expected %v, got %v", test.want, err)
+			}
+			if isNetworkError(err) != test.network {
+				t.Fatalf("expected network error to be %t for %v", test.network, err)
+			}
+			if !errors.Is(err, cause) {
+				t.Fatalf("expected underlying error to be wrapped")
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name       string
+		attempt    int
+		retryAfter string
+		min, max   time.Duration
+	}{
+		{name: "first backoff", attempt: 0, min: retryBaseDelay, max: retryBaseDelay * 3 / 2},
+		{name: "third backoff", attempt: 2, min: 4 * retryBaseDelay, max: 6 * retryBaseDelay},
+		{name: "backoff capped", attempt: 20, min: retryMaxDelay, max: retryMaxDelay},
+		{name: "retry after seconds", attempt: 0, retryAfter: "7", min: 7 * time.Second, max: 7 * time.Second},
+		{name: "retry after date", attempt: 0, retryAfter: now.Add(3 * time.Second).Format(http.TimeFormat), min: 3 * time.Second, max: 3 * time.Second},
+		{name: "retry after capped", attempt: 0, retryAfter: "3600", min: retryMaxDelay, max: retryMaxDelay},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			got := retryDelay(test.attempt, test.retryAfter, now)
+			if got < test.min || got > test.max {
+				t.Fatalf("delay %s not in [%s, %s]", got, test.min, test.max)
+			}
+		})
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	testCases := []struct {
+		name      string
+		statuses  []int
+		want      int
+		wantCalls int
+	}{
+		{
+			name:      "retries rate limited request",
+			statuses:  []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusOK},
+			want:      http.StatusOK,
+			wantCalls: 3,
+		},
+		{
+			name:      "does not retry not found",
+			statuses:  []int{http.StatusNotFound},
+			want:      http.StatusNotFound,
+			wantCalls: 1,
+		},
+		{
+			name:      "gives up after max retries",
+			statuses:  []int{429, 429, 429, 429, 429, 429},
+			want:      http.StatusTooManyRequests,
+			wantCalls: maxScrapeRetries + 1,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(test.statuses[min(calls, len(test.statuses)-1)])
+				calls++
+			}))
+			defer server.Close()
+			client := http.Client{Transport: &retryTransport{base: http.DefaultTransport}}
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			_ = resp.Body.Close()
+			if resp.StatusCode != test.want {
+				t.Fatalf("expected status %d, got %d", test.want, resp.StatusCode)
+			}
+			if calls != test.wantCalls {
+				t.Fatalf("expected %d calls, got %d", test.wantCalls, calls)
+			}
+		})
+	}
+}
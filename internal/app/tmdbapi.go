@@ -28,12 +28,23 @@ func TMDBFilm(id int) (*tmdb.MovieDetails, error) {
 	if TMDBClient == nil {
 		return nil, ErrNoAPI
 	}
-	film, err := TMDBClient.GetMovieDetails(id, map[string]string{
-		"append_to_response": "credits",
-	})
+	if err := checkOnline(); err != nil {
+		return nil, err
+	}
+	appends := []string{"credits", "videos"}
+	if Config.TMDB.Region != "" {
+		appends = append(appends, "watch/providers", "release_dates")
+	}
+	film, err := TMDBClient.GetMovieDetails(id, localeOptions(map[string]string{
+		"append_to_response":     strings.Join(appends, ","),
+		"include_video_language": videoLanguages(),
+	}, false))
 	if err != nil {
 		return nil, fmt.Errorf("%w, with id %d, %w", ErrFailedTMDBLookup, id, err)
 	}
+	trimWatchProviders(film, Config.TMDB.Region)
+	trimReleaseDates(film, Config.TMDB.Region)
+	trimVideos(film)
 	return film, nil
 }
 
@@ -42,6 +53,9 @@ func SearchFilms(query string) ([]tmdb.MovieResult, error) {
 	if TMDBClient == nil {
 		return nil, ErrNoAPI
 	}
+	if err := checkOnline(); err != nil {
+		return nil, err
+	}
 	q := strings.TrimSpace(query)
 	if q == "" {
 		return nil, fmt.Errorf("query cannot be empty")
@@ -51,7 +65,7 @@ func SearchFilms(query string) ([]tmdb.MovieResult, error) {
 		q = match[1]
 		urlOpts["year"] = match[2]
 	}
-	res, err := TMDBClient.GetSearchMovies(q, urlOpts)
+	res, err := TMDBClient.GetSearchMovies(q, localeOptions(urlOpts, true))
 	if err != nil {
 		return nil, fmt.Errorf("tmdb search failed, %w", err)
 	}
@@ -68,3 +82,58 @@ func ReleaseYear(mr tmdb.MovieResult) (int, error) {
 	}
 	return releaseDate.Year(), nil
 }
+
+// Queries TMDB for films recommended for (or similar to) the film with the
+// given id. Recommendations come first, followed by similar films that were
+// not recommended.
+func SimilarFilms(id int) ([]tmdb.MovieResult, error) {
+	if TMDBClient == nil {
+		return nil, ErrNoAPI
+	}
+	if err := checkOnline(); err != nil {
+		return nil, err
+	}
+	recs, err := TMDBClient.GetMovieRecommendations(id, localeOptions(map[string]string{"page": "1"}, false))
+	if err != nil {
+		return nil, fmt.Errorf("%w, recommendations for id %d, %w", ErrFailedTMDBLookup, id, err)
+	}
+	similar, err := TMDBClient.GetMovieSimilar(id, localeOptions(map[string]string{"page": "1"}, false))
+	if err != nil {
+		return nil, fmt.Errorf("%w, similar films for id %d, %w", ErrFailedTMDBLookup, id, err)
+	}
+	results := make([]tmdb.MovieResult, 0)
+	seen := make(map[int64]bool)
+	for _, page := range []*tmdb.MovieRecommendationsResults{recs.MovieRecommendationsResults, similarResults(similar)} {
+		if page == nil {
+			continue
+		}
+		for _, r := range page.Results {
+			if seen[r.ID] || r.Adult {
+				continue
+			}
+			seen[r.ID] = true
+			results = append(results, tmdb.MovieResult{
+				ID:               r.ID,
+				Title:            r.Title,
+				OriginalTitle:    r.OriginalTitle,
+				OriginalLanguage: r.OriginalLanguage,
+				Overview:         r.Overview,
+				ReleaseDate:      r.ReleaseDate,
+				PosterPath:       r.PosterPath,
+				BackdropPath:     r.BackdropPath,
+				Popularity:       r.Popularity,
+				GenreIDs:         r.GenreIDs,
+				Video:            r.Video,
+				VoteMetrics:      r.VoteMetrics,
+			})
+		}
+	}
+	return results, nil
+}
+
+func similarResults(similar *tmdb.MovieSimilar) *tmdb.MovieRecommendationsResults {
+	if similar == nil || similar.MovieRecommendations == nil {
+		return nil
+	}
+	return similar.MovieRecommendationsResults
+}
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+var ErrNoTrailerPlayer = errors.New("no trailer player configured")
+
+// Urls videos are played from by site (as named by TMDB).
+var videoSites = map[string]string{
+	"YouTube": "https://www.youtube.com/watch?v=",
+	"Vimeo":   "https://vimeo.com/",
+}
+
+// Video types that are kept (in order of preference).
+var trailerTypes = []string{"Trailer", "Teaser"}
+
+// Film trailer hosted on YouTube or Vimeo.
+type Trailer struct {
+	Name string // name of video (e.g., "Official Trailer")
+	Url  string // url of video on the site it is hosted on
+}
+
+// Best trailer for the film. Returns false if the film has no trailers (or
+// videos were not fetched).
+func (fr *FilmRecord) Trailer() (Trailer, bool) {
+	if fr.Details == nil {
+		return Trailer{}, false
+	}
+	return bestTrailer(fr.Details)
+}
+
+// Picks trailers over teasers, official videos over unofficial ones, then the
+// highest resolution, then the earliest published video.
+func bestTrailer(details *tmdb.MovieDetails) (Trailer, bool) {
+	videos := playableVideos(details)
+	if len(videos) == 0 {
+		return Trailer{}, false
+	}
+	best := slices.MinFunc(videos, func(a, b tmdb.VideoResult) int {
+		return cmp.Or(
+			cmp.Compare(slices.Index(trailerTypes, a.Type), slices.Index(trailerTypes, b.Type)),
+			compareBool(b.Official, a.Official),
+			cmp.Compare(b.Size, a.Size),
+			strings.Compare(a.PublishedAt, b.PublishedAt),
+		)
+	})
+	return Trailer{Name: best.Name, Url: videoSites[best.Site] + best.Key}, true
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	}
+	return -1
+}
+
+// Trailers and teasers on sites we can link to.
+func playableVideos(details *tmdb.MovieDetails) []tmdb.VideoResult {
+	if details.MovieVideosAppend == nil || details.Videos == nil {
+		return nil
+	}
+	return slices.DeleteFunc(slices.Clone(details.Videos.Results), func(v tmdb.VideoResult) bool {
+		_, ok := videoSites[v.Site]
+		return !ok || !slices.Contains(trailerTypes, v.Type)
+	})
+}
+
+// Drops videos other than trailers, since details are saved and TMDB returns
+// every featurette and clip.
+func trimVideos(details *tmdb.MovieDetails) {
+	if details.MovieVideosAppend == nil || details.Videos == nil {
+		return
+	}
+	details.Videos.Results = playableVideos(details)
+}
+
+// Languages of videos to fetch from TMDB. By default TMDB only returns videos
+// in the request's language, so English and untagged videos are included too.
+func videoLanguages() string {
+	lang, _, _ := strings.Cut(Config.TMDB.Language, "-")
+	if lang == "" || lang == "en" {
+		return "en,null"
+	}
+	return lang + ",en,null"
+}
+
+// Opens trailer in the player set in the config (e.g., "mpv"). The url is
+// passed as the last argument.
+func PlayTrailer(t Trailer) error {
+	args := strings.Fields(Config.Features.TrailerPlayer)
+	if len(args) == 0 {
+		return ErrNoTrailerPlayer
+	}
+	cmd := exec.Command(args[0], append(args[1:], t.Url)...)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("could not start %s, %w", args[0], err)
+	}
+	go func() { _ = cmd.Wait() }() // reap player once it exits
+	return nil
+}
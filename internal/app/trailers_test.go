@@ -0,0 +1,98 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+func videoDetails(videos ...tmdb.VideoResult) *tmdb.MovieDetails {
+	return &tmdb.MovieDetails{MovieVideosAppend: &tmdb.MovieVideosAppend{Videos: &tmdb.VideoResults{Results: videos}}}
+}
+
+func TestBestTrailer(t *testing.T) {
+	teaser := tmdb.VideoResult{Name: "Teaser", Site: "YouTube", Key: "teaser", Type: "Teaser", Official: true, Size: 1080}
+	fanTrailer := tmdb.VideoResult{Name: "Fan Trailer", Site: "YouTube", Key: "fan", Type: "Trailer", Size: 2160}
+	trailer := tmdb.VideoResult{Name: "Official Trailer", Site: "YouTube", Key: "official", Type: "Trailer", Official: true, Size: 1080, PublishedAt: "2019-04-01T00:00:00.000Z"}
+	laterTrailer := tmdb.VideoResult{Name: "Trailer 2", Site: "YouTube", Key: "second", Type: "Trailer", Official: true, Size: 1080, PublishedAt: "2019-09-01T00:00:00.000Z"}
+	vimeo := tmdb.VideoResult{Name: "Vimeo Teaser", Site: "Vimeo", Key: "123", Type: "Teaser"}
+	clip := tmdb.VideoResult{Name: "Clip", Site: "YouTube", Key: "clip", Type: "Clip", Official: true}
+	unknownSite := tmdb.VideoResult{Name: "Trailer", Site: "Dailymotion", Key: "x", Type: "Trailer", Official: true}
+	testCases := []struct {
+		name    string
+		details *tmdb.MovieDetails
+		want    Trailer
+		wantOk  bool
+	}{
+		{
+			name:    "official trailer",
+			details: videoDetails(teaser, fanTrailer, laterTrailer, trailer, clip),
+			want:    Trailer{Name: "Official Trailer", Url: "https://www.youtube.com/watch?v=official"},
+			wantOk:  true,
+		},
+		{
+			name:    "trailer over teaser",
+			details: videoDetails(teaser, fanTrailer),
+			want:    Trailer{Name: "Fan Trailer", Url: "https://www.youtube.com/watch?v=fan"},
+			wantOk:  true,
+		},
+		{
+			name:    "vimeo",
+			details: videoDetails(vimeo, clip, unknownSite),
+			want:    Trailer{Name: "Vimeo Teaser", Url: "https://vimeo.com/123"},
+			wantOk:  true,
+		},
+		{name: "no trailers", details: videoDetails(clip, unknownSite)},
+		{name: "videos not fetched", details: &tmdb.MovieDetails{}},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			fr := FilmRecord{Details: test.details}
+			got, ok := fr.Trailer()
+			if ok != test.wantOk {
+				t.Fatalf("want ok %t, got %t", test.wantOk, ok)
+			}
+			if got != test.want {
+				t.Errorf("want %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestTrimVideos(t *testing.T) {
+	details := videoDetails(
+		tmdb.VideoResult{Site: "YouTube", Type: "Trailer"},
+		tmdb.VideoResult{Site: "YouTube", Type: "Featurette"},
+		tmdb.VideoResult{Site: "Dailymotion", Type: "Trailer"},
+	)
+	trimVideos(details)
+	if len(details.Videos.Results) != 1 {
+		t.Errorf("expected only the YouTube trailer to be kept, got %v", details.Videos.Results)
+	}
+}
+
+func TestVideoLanguages(t *testing.T) {
+	prev := Config.TMDB
+	t.Cleanup(func() { Config.TMDB = prev })
+	for lang, want := range map[string]string{"": "en,null", "en-US": "en,null", "fr-FR": "fr,en,null", "de": "de,en,null"} {
+		Config.TMDB.Language = lang
+		if got := videoLanguages(); got != want {
+			t.Errorf("language %q: want %s, got %s", lang, want, got)
+		}
+	}
+}
+
+func TestPlayTrailer(t *testing.T) {
+	prev := Config.Features.TrailerPlayer
+	t.Cleanup(func() { Config.Features.TrailerPlayer = prev })
+	trailer := Trailer{Name: "Trailer", Url: "https://www.youtube.com/watch?v=x"}
+	Config.Features.TrailerPlayer = " "
+	if err := PlayTrailer(trailer); !errors.Is(err, ErrNoTrailerPlayer) {
+		t.Errorf("expected error %v, got %v", ErrNoTrailerPlayer, err)
+	}
+	Config.Features.TrailerPlayer = "nw-test-player-that-does-not-exist --fs"
+	if err := PlayTrailer(trailer); err == nil {
+		t.Error("expected error starting missing player")
+	}
+}
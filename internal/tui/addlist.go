@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
@@ -118,13 +119,74 @@ func (li searchListsItem) Title() string {
 }
 
 func (li searchListsItem) Description() string {
-	desc := fmt.Sprintf("%d films", li.fl.NumFilms)
+	parts := make([]string, 0, 3)
+	if li.fl.NumFilms >= 0 {
+		parts = append(parts, fmt.Sprintf("%d films", li.fl.NumFilms))
+	}
+	if meta := listMetaString(li.fl); meta != "" {
+		parts = append(parts, meta)
+	}
+	desc := strings.Join(parts, " :: ")
 	if len(li.fl.Desc) != 0 {
 		desc += fmt.Sprintf(" :: %s", li.fl.Desc)
 	}
 	return desc
 }
 
+// Item for following the member whose lists are being browsed.
+type followMemberItem struct {
+	username string
+	numLists int
+	app      *ApplicationTUI
+}
+
+func (fi *followMemberItem) FilterValue() string {
+	return "@" + fi.username
+}
+
+func (fi *followMemberItem) Title() string {
+	if fi.app.IsFollowing(fi.username) {
+		return fmt.Sprintf("Following @%s", fi.username)
+	}
+	return fmt.Sprintf("Follow @%s", fi.username)
+}
+
+func (fi *followMemberItem) Description() string {
+	if fi.app.IsFollowing(fi.username) {
+		return fmt.Sprintf("%d lists :: new lists are shown with yours", fi.numLists)
+	}
+	return fmt.Sprintf("%d lists :: follow to show their lists with yours", fi.numLists)
+}
+
+// Item for making a Next Watch queue shared with the member whose lists are
+// being browsed.
+type shareQueueItem struct {
+	username string
+	app      *ApplicationTUI
+}
+
+func (si *shareQueueItem) sharing() bool {
+	return si.app.SharingQueue(si.username)
+}
+
+func (si *shareQueueItem) FilterValue() string {
+	return "@" + si.username
+}
+
+func (si *shareQueueItem) Title() string {
+	if si.sharing() {
+		return fmt.Sprintf("Sharing queue with @%s", si.username)
+	}
+	return fmt.Sprintf("Watch together with @%s", si.username)
+}
+
+func (si *shareQueueItem) Description() string {
+	if si.sharing() {
+		return "press enter to stop sharing"
+	}
+	return "make a queue from films on both of your watchlists"
+}
+
 type searchListsDelegate struct {
 	list.DefaultDelegate
 	app *ApplicationTUI
@@ -139,6 +201,18 @@ func (d searchListsDelegate) Update(msg tea.Msg, ls *list.Model) tea.Cmd {
 	if item == nil {
 		return nil
 	}
+	if fi, ok := item.(*followMemberItem); ok {
+		if msg, ok := msg.(tea.KeyMsg); ok && msg.Type == tea.KeyEnter {
+			return d.toggleFollow(fi)
+		}
+		return nil
+	}
+	if si, ok := item.(*shareQueueItem); ok {
+		if msg, ok := msg.(tea.KeyMsg); ok && msg.Type == tea.KeyEnter {
+			return d.toggleShare(si)
+		}
+		return nil
+	}
 	li, ok := item.(*searchListsItem)
 	if !ok {
 		panic(fmt.Sprintf("(Add List) ListSelector item should be addFilmlistItem, instead item is %T", ls.SelectedItem()))
@@ -175,12 +249,44 @@ func (d searchListsDelegate) Update(msg tea.Msg, ls *list.Model) tea.Cmd {
 	return nil
 }
 
+func (d searchListsDelegate) toggleFollow(fi *followMemberItem) tea.Cmd {
+	if d.app.IsFollowing(fi.username) {
+		d.app.UnfollowMember(fi.username)
+		return UpdateScreen
+	}
+	if err := d.app.FollowMember(fi.username); err != nil {
+		log.Print(err.Error())
+		return statusMessageCmd(Message{text: err.Error(), error: true})
+	}
+	return UpdateScreen
+}
+
+func (d searchListsDelegate) toggleShare(si *shareQueueItem) tea.Cmd {
+	if si.sharing() {
+		d.app.StopSharingQueue(si.username)
+		return UpdateScreen
+	}
+	return func() tea.Msg {
+		if err := d.app.ShareQueue(si.username); err != nil {
+			log.Print(err.Error())
+			return statusMessageMsg{Message{text: err.Error(), error: true}}
+		}
+		return statusMessageMsg{Message{text: fmt.Sprintf("queue shared with %s, press %s on your queue to switch", si.username, keys.SwitchQueue.Help().Key)}}
+	}
+}
+
 func (d searchListsDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	dd := d.DefaultDelegate
+	switch listItem.(type) {
+	case *followMemberItem, *shareQueueItem:
+		dd.Styles.NormalTitle = dd.Styles.NormalTitle.Foreground(luster).Bold(true)
+		dd.Render(w, m, index, listItem)
+		return
+	}
 	li, ok := listItem.(*searchListsItem)
 	if !ok {
 		panic(fmt.Sprintf("(Add List) ListSelector item should be addFilmlistItem, instead item is %T", listItem))
 	}
-	dd := d.DefaultDelegate
 	if d.app.IsListTracked(li.fl.Url) {
 		dd.Styles.NormalTitle = dd.Styles.NormalTitle.
 			Foreground(luster).Bold(true)
@@ -190,15 +296,94 @@ func (d searchListsDelegate) Render(w io.Writer, m list.Model, index int, listIt
 	dd.Render(w, m, index, listItem)
 }
 
-func MakeSearchListPane(a *ApplicationTUI) *SearchModel {
-	items := make([]list.Item, 0, len(a.ListHeaders))
+// Items for the user's own lists and the lists of members they follow.
+func userListItems(a *ApplicationTUI) []list.Item {
+	followed := a.FollowedLists()
+	items := make([]list.Item, 0, len(a.ListHeaders)+len(followed))
 	for _, lh := range a.ListHeaders {
 		items = append(items, &searchListsItem{lh})
 	}
+	for _, fl := range app.MemberPages(a.Username) {
+		if fl.Kind == app.KindLikes { // user's watchlist is already used by the queue
+			items = append(items, &searchListsItem{fl})
+		}
+	}
+	for _, fl := range followed {
+		items = append(items, &searchListsItem{fl})
+	}
+	return items
+}
+
+// Member's lists scraped in the background, to be stored on the update loop.
+type memberListsMsg struct {
+	username string
+	scrape   app.MemberListsScrape
+	query    string // search query the lists were scraped for
+}
+
+// Stores member's scraped lists and shows them in the search pane.
+func memberListsCmd(a *ApplicationTUI, msg memberListsMsg) tea.Cmd {
+	lists, err := a.ApplyMemberLists(msg.scrape)
+	if err != nil {
+		log.Printf("could not get lists of %s, %s", msg.username, err)
+		return statusMessageCmd(Message{text: fmt.Sprintf("could not get lists of %s", msg.username), error: true})
+	}
+	items := memberListItems(a, strings.ToLower(msg.username), lists)
+	return func() tea.Msg { return UpdateSearchItemsMsg{items: items, query: msg.query} }
+}
+
+// Items for browsing a member's lists, starting with the options to follow
+// them and to share a queue with them.
+func memberListItems(a *ApplicationTUI, username string, lists []*app.FilmList) []list.Item {
+	items := make([]list.Item, 0, len(lists)+4)
+	items = append(items,
+		&followMemberItem{username: username, numLists: len(lists), app: a},
+		&shareQueueItem{username: username, app: a},
+	)
+	for _, fl := range app.MemberPages(username) {
+		items = append(items, &searchListsItem{fl})
+	}
+	for _, fl := range lists {
+		items = append(items, &searchListsItem{fl})
+	}
+	return items
+}
+
+func MakeSearchListPane(a *ApplicationTUI) *SearchModel {
+	member := "" // "@member" whose lists are shown, empty when showing the user's lists
 	inputChangeAction := func(s string) tea.Cmd {
-		return func() tea.Msg { return UpdateSearchFilterMsg{filter: s} }
+		prefix, filter := "", s
+		if strings.HasPrefix(s, "@") { // filter member's lists by the text after their username
+			var rest string
+			prefix, rest, _ = strings.Cut(s, " ")
+			filter = ""
+			if prefix == member {
+				filter = strings.TrimSpace(rest)
+			}
+		}
+		filterCmd := func() tea.Msg { return UpdateSearchFilterMsg{filter: filter} }
+		if s != "" && (member == "" || prefix == member) {
+			return filterCmd
+		}
+		member = ""
+		items := userListItems(a)
+		return tea.Batch(filterCmd, func() tea.Msg {
+			return UpdateSearchItemsMsg{items: items, query: s}
+		})
 	}
 	queryEnterAction := func(s string, _ list.Item) tea.Cmd {
+		if strings.HasPrefix(s, "@") {
+			prefix, _, _ := strings.Cut(s, " ")
+			member = prefix
+			username := strings.TrimPrefix(prefix, "@")
+			if lists, ok := a.SavedMemberLists(username); ok {
+				items := memberListItems(a, strings.ToLower(username), lists)
+				return func() tea.Msg { return UpdateSearchItemsMsg{items: items, query: s} }
+			}
+			return func() tea.Msg {
+				return memberListsMsg{username: username, scrape: app.ScrapeMemberLists(username), query: s}
+			}
+		}
 		return func() tea.Msg {
 			if err := a.AddListFromUrl(s); !errors.Is(err, app.ErrInvalidUrl) {
 				log.Printf("could not add query as url, %s", err)
@@ -208,8 +393,8 @@ func MakeSearchListPane(a *ApplicationTUI) *SearchModel {
 	}
 	return MakeSearchModel(
 		a,
-		items,
-		"Enter URL or search lists...",
+		userListItems(a),
+		"Enter URL, @member, or search lists...",
 		searchListsDelegate{listStyleDelegate(), a},
 		normalMode,
 		inputChangeAction,
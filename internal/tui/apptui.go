@@ -73,7 +73,7 @@ func RunApplicationTUI(username string) error {
 func (a *ApplicationTUI) Init() tea.Cmd {
 	a.status = *MakeStatusBar(a)
 	a.help = help.New()
-	return updateUserDataCmd(a, true)
+	return tea.Batch(updateUserDataCmd(a, true), waitForFilmRefreshCmd(a.FilmStore.Refreshes()))
 }
 
 func (a *ApplicationTUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -90,11 +90,19 @@ func (a *ApplicationTUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.height = msg.Height
 		a.help.Width = a.width
 	case userDataLoadedMsg:
-		a.screens.pop()          // remove loading screen
+		a.screens.pop() // remove loading screen
+		for _, change := range msg.changes {
+			cmds = append(cmds, a.status.setMessage(Message{text: change.String()}))
+		}
+		for _, err := range msg.errs {
+			cmds = append(cmds, a.status.setMessage(Message{text: err.Error(), error: true}))
+		}
 		if len(a.screens) == 0 { // we need different behavior on startup vs. update
-			a.screens.push(MakeMainScreen(a))
+			ms := MakeMainScreen(a)
+			a.screens.push(ms)
+			cmds = append(cmds, ms.Init())
 		} else {
-			return a, UpdateScreen
+			return a, tea.Batch(append(cmds, UpdateScreen)...)
 		}
 	case userDataFailedMsg:
 		if ss, ok := a.screens.cur().(*SplashScreenModel); ok {
@@ -104,6 +112,13 @@ func (a *ApplicationTUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case statusMessageMsg:
 		cmds = append(cmds, a.status.setMessage(msg.message))
+	case addToLocalListMsg:
+		return a, addToLocalListCmd(a, msg)
+	case memberListsMsg:
+		return a, memberListsCmd(a, msg)
+	case filmRefreshMsg:
+		msg.refresh.Apply()
+		return a, tea.Batch(waitForFilmRefreshCmd(a.FilmStore.Refreshes()), UpdateScreen)
 	case GoBackMsg:
 		if len(a.screens) == 1 {
 			return a, tea.Quit
@@ -123,6 +138,9 @@ func (a *ApplicationTUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, keys.About):
 			a.Popup(About)
 			return a, nil
+		case key.Matches(msg, keys.History):
+			a.Popup(historyText(a.History))
+			return a, nil
 		}
 	}
 	cmds = append(cmds, a.UpdateRouter(msg)...)
@@ -163,8 +181,18 @@ func (a *ApplicationTUI) TooSmall() bool {
 	return a.width <= paneWidth || a.height <= paneHeight
 }
 
-type userDataLoadedMsg struct{}
+type userDataLoadedMsg struct {
+	changes []app.ListDiff
+	errs    []error // problems that did not stop the update
+}
 type userDataFailedMsg struct{ err error }
+type filmRefreshMsg struct{ refresh app.FilmRefresh }
+
+// Waits for a film record to be refreshed in the background, so the refresh
+// can be applied in Update (where film records are read).
+func waitForFilmRefreshCmd(refreshes <-chan app.FilmRefresh) tea.Cmd {
+	return func() tea.Msg { return filmRefreshMsg{<-refreshes} }
+}
 
 func updateUserDataCmd(app *ApplicationTUI, check bool) tea.Cmd {
 	if len(app.screens) != 0 { // check to prevent user spamming Update key
@@ -178,6 +206,6 @@ func updateUserDataCmd(app *ApplicationTUI, check bool) tea.Cmd {
 		if err := app.UpdateUserData(check); err != nil {
 			return userDataFailedMsg{err}
 		}
-		return userDataLoadedMsg{}
+		return userDataLoadedMsg{changes: app.TakeListChanges(), errs: app.TakeUpdateErrors()}
 	})
 }
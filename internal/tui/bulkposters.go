@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jsdoublel/nw/internal/app"
+)
+
+const postersFailedShown = 3 // titles of failed films listed in status bar
+
+type postersProgressMsg struct {
+	name        string // list or queue posters are downloaded for
+	done, total int
+	updates     <-chan tea.Msg
+}
+
+type postersDoneMsg struct {
+	name    string
+	results []app.PosterResult
+}
+
+// Starts looking up films and downloading their posters in the background.
+// Progress is shown in the status bar until the downloads finish, when a
+// summary is shown.
+func downloadPostersCmd(a *ApplicationTUI, name string, films []*app.Film) tea.Cmd {
+	if len(films) == 0 {
+		return statusMessageCmd(Message{text: fmt.Sprintf("No films in %s to download posters for", name), error: true})
+	}
+	if _, ok := a.status.posters[name]; ok {
+		return statusMessageCmd(Message{text: fmt.Sprintf("Already downloading posters for %s", name), error: true})
+	}
+	a.status.posters[name] = postersProgressMsg{name: name, total: len(films)}
+	updates := make(chan tea.Msg)
+	go func() {
+		results := a.DownloadPosters(films, func(done, total int) {
+			updates <- postersProgressMsg{name: name, done: done, total: total, updates: updates}
+		})
+		updates <- postersDoneMsg{name: name, results: results}
+	}()
+	return waitForPostersCmd(updates)
+}
+
+func waitForPostersCmd(updates <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg { return <-updates }
+}
+
+// Summary of finished downloads, naming some of the films that failed (all
+// failures are logged).
+func postersSummary(msg postersDoneMsg) Message {
+	downloaded, skipped, failed := app.SummarizePosters(msg.results)
+	text := fmt.Sprintf("Posters for %s: %d downloaded, %d already downloaded", msg.name, downloaded, skipped)
+	if len(failed) == 0 {
+		return Message{text: text}
+	}
+	titles := make([]string, 0, postersFailedShown)
+	for i, r := range failed {
+		log.Printf("could not download poster for %s, %s", r.Film, r.Err)
+		if i < postersFailedShown {
+			titles = append(titles, r.Film.Title)
+		}
+	}
+	if len(failed) > postersFailedShown {
+		titles = append(titles, fmt.Sprintf("%d more", len(failed)-postersFailedShown))
+	}
+	text += fmt.Sprintf(", %d failed (%s)", len(failed), strings.Join(titles, ", "))
+	return Message{text: text, error: true}
+}
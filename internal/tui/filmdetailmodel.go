@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	tmdb "github.com/cyruzin/golang-tmdb"
@@ -26,6 +27,7 @@ type FilmDetailsModel struct {
 	focused        bool
 	actions        []FilmAction
 	selectedAction int
+	viewport       viewport.Model // scrolls details when they do not fit in the pane
 	style          lipgloss.Style
 	app            *ApplicationTUI
 	err            error // records error if film details could not be retrieved
@@ -41,7 +43,7 @@ func init() { // discrads output from calling OpenURL which messes with View
 	browser.Stderr = io.Discard
 }
 
-func (fd *FilmDetailsModel) Init() tea.Cmd { return nil }
+func (fd *FilmDetailsModel) Init() tea.Cmd { return posterCmd(fd.film) }
 
 func (fd *FilmDetailsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if msg, ok := msg.(tea.KeyMsg); ok {
@@ -50,6 +52,10 @@ func (fd *FilmDetailsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			fd.actionRight()
 		case key.Matches(msg, keys.Left):
 			fd.actionLeft()
+		case key.Matches(msg, keys.ScrollUp):
+			fd.viewport.HalfPageUp()
+		case key.Matches(msg, keys.ScrollDown):
+			fd.viewport.HalfPageDown()
 		case msg.Type == tea.KeyEnter && fd.film != nil:
 			cmd, err := fd.actions[fd.selectedAction].action(*fd.film)
 			if err != nil {
@@ -67,8 +73,25 @@ func (fd *FilmDetailsModel) View() string {
 	if fd.err != nil {
 		return fd.style.Foreground(red).Width(paneWidth).Render(fd.errorText())
 	}
+	actions := fd.renderActions()
 	return fd.style.Width(paneWidth).
-		Render(lipgloss.JoinVertical(lipgloss.Center, fd.renderDetails(), "", fd.renderActions()))
+		Render(lipgloss.JoinVertical(lipgloss.Center, fd.scrollDetails(fd.renderDetails(), actions), "", actions))
+}
+
+// Shows details in a scrollable viewport if they do not fit in the pane
+// along with the actions.
+func (fd *FilmDetailsModel) scrollDetails(details, actions string) string {
+	maxHeight := paneHeight - fd.style.GetVerticalFrameSize() - lipgloss.Height(actions) - 1
+	if lipgloss.Height(details) <= maxHeight {
+		fd.viewport.SetYOffset(0)
+		return details
+	}
+	fd.viewport.Width = lipgloss.Width(details)
+	fd.viewport.Height = maxHeight - 1 // leave room for scroll hint
+	fd.viewport.SetContent(details)
+	hint := fmt.Sprintf("%s/%s to scroll (%d%%)",
+		keys.ScrollUp.Help().Key, keys.ScrollDown.Help().Key, int(fd.viewport.ScrollPercent()*100))
+	return lipgloss.JoinVertical(lipgloss.Center, fd.viewport.View(), filmScrollHintStyle.Render(hint))
 }
 
 func (fd *FilmDetailsModel) Focus() {
@@ -93,21 +116,50 @@ func (fd *FilmDetailsModel) renderDetails() string {
 	_, rightPad, _, leftPad := filmDetailsStyle.GetPadding()
 	colWidthRight := paneWidth/2 - rightPad
 	colWidthLeft := paneWidth/2 - leftPad
-	rightText := filmTextStyle.Width(colWidthRight).Render(fd.film.Details.Overview)
+	overview := fd.film.Details.Overview
+	if tagline := fd.film.Details.Tagline; tagline != "" {
+		overview = filmNoteStyle.Render(tagline) + "\n\n" + overview
+	}
+	rightText := filmTextStyle.Width(colWidthRight).Render(overview + fd.notesText())
 	if len([]rune(fd.film.String())) > colWidthRight {
 		rightText = "\n" + rightText
 	}
-	limitAdj := 2 // adjust cast length limit based on height of text above
 	var b strings.Builder
 	if directors := fd.film.DirectorString(); directors != "" {
 		b.WriteString(flimDirStyle.Render(directors))
-		limitAdj++
 	}
-	if runtime := fd.film.Details.Runtime; runtime > 0 {
-		b.WriteString(fmt.Sprintf("\n%d minutes", runtime))
-		limitAdj++
+	for _, line := range []string{fd.factsLine(), fd.genresLine(), fd.countriesLine()} {
+		if line != "" {
+			b.WriteString("\n")
+			b.WriteString(line)
+		}
+	}
+	if ratings := fd.averageRatingsLine(); ratings != "" {
+		b.WriteString("\n")
+		b.WriteString(ratings)
+	}
+	if t, ok := fd.film.Trailer(); ok {
+		b.WriteString(fmt.Sprintf("\n\u25b6 %s", t.Name))
+	}
+	if rating := fd.ratingLine(); rating != "" {
+		b.WriteString("\n")
+		b.WriteString(filmRatingStyle.Render(rating))
+	}
+	if collection := fd.collectionText(); collection != "" {
+		b.WriteString("\n\n")
+		b.WriteString(collection)
+	}
+	if providers := fd.providersText(); providers != "" {
+		b.WriteString("\n\n")
+		b.WriteString(providers)
 	}
-	castLimit := max(minCast, lipgloss.Height(rightText)-limitAdj)
+	poster := posterView(fd.film)
+	// fill space beside the overview with cast (2 lines for gap and header)
+	above := lipgloss.Height(filmTextStyle.Width(colWidthLeft).Render(b.String()))
+	if poster != "" {
+		above += lipgloss.Height(poster) + 1
+	}
+	castLimit := max(minCast, lipgloss.Height(rightText)-above-2)
 	if cast := fd.castLine(castLimit); cast != "" {
 		b.WriteString("\n\n")
 		b.WriteString(filmCastHeaderStyle.Render("Cast"))
@@ -115,6 +167,15 @@ func (fd *FilmDetailsModel) renderDetails() string {
 		b.WriteString(cast)
 	}
 	leftText := filmTextStyle.Width(colWidthLeft).Render(b.String())
+	if poster != "" {
+		leftText = lipgloss.JoinVertical(lipgloss.Left, poster, "", leftText)
+	}
+	if original := fd.originalTitle(); original != "" {
+		title = lipgloss.JoinVertical(lipgloss.Left, title, filmNoteStyle.Render(original))
+	}
+	if stale := fd.staleLine(); stale != "" {
+		title = lipgloss.JoinVertical(lipgloss.Left, title, stale)
+	}
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		title,
@@ -122,6 +183,144 @@ func (fd *FilmDetailsModel) renderDetails() string {
 	)
 }
 
+// Original title of the film, if it differs from the title shown.
+func (fd *FilmDetailsModel) originalTitle() string {
+	original := fd.film.Details.OriginalTitle
+	if original == "" || strings.EqualFold(original, fd.film.Title) || strings.EqualFold(original, fd.film.Details.Title) {
+		return ""
+	}
+	return original
+}
+
+// Runtime and age certification (e.g., "112 minutes \u00b7 R").
+func (fd *FilmDetailsModel) factsLine() string {
+	facts := make([]string, 0, 2)
+	if runtime := fd.film.Details.Runtime; runtime > 0 {
+		facts = append(facts, fmt.Sprintf("%d minutes", runtime))
+	}
+	if cert := fd.film.Certification(); cert != "" {
+		facts = append(facts, cert)
+	}
+	return strings.Join(facts, " \u00b7 ")
+}
+
+func (fd *FilmDetailsModel) genresLine() string {
+	genres := make([]string, len(fd.film.Details.Genres))
+	for i, g := range fd.film.Details.Genres {
+		genres[i] = g.Name
+	}
+	return strings.Join(genres, ", ")
+}
+
+func (fd *FilmDetailsModel) countriesLine() string {
+	countries := make([]string, len(fd.film.Details.ProductionCountries))
+	for i, c := range fd.film.Details.ProductionCountries {
+		countries[i] = c.Name
+	}
+	return strings.Join(countries, ", ")
+}
+
+// Average ratings on Letterboxd (out of 5) and TMDB (out of 10).
+func (fd *FilmDetailsModel) averageRatingsLine() string {
+	ratings := make([]string, 0, 2)
+	if r := fd.film.LBxdRating; r > 0 {
+		ratings = append(ratings, fmt.Sprintf("Letterboxd %.2f", r))
+	}
+	if r := fd.film.Details.VoteAverage; r > 0 && fd.film.Details.VoteCount > 0 {
+		ratings = append(ratings, fmt.Sprintf("TMDB %.1f", r))
+	}
+	return strings.Join(ratings, " \u00b7 ")
+}
+
+// Notes left on the film in tracked lists.
+func (fd *FilmDetailsModel) notesText() string {
+	var b strings.Builder
+	for _, n := range fd.app.ListNotes(fd.film.Film) {
+		b.WriteString("\n\n")
+		b.WriteString(filmNoteStyle.Render(fmt.Sprintf("%s: %s", n.List, n.Note)))
+	}
+	return b.String()
+}
+
+// Collection the film belongs to, the user's progress through it, and any
+// earlier films in it the user has not watched.
+func (fd *FilmDetailsModel) collectionText() string {
+	watched, released, ok := fd.app.CollectionProgress(fd.film)
+	if !ok {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(filmCastHeaderStyle.Render(fd.film.Collection.Name))
+	b.WriteString(fmt.Sprintf("\nWatched %d of %d", watched, released))
+	preds := fd.film.Collection.UnwatchedPredecessors(fd.film.TMDBID, fd.app.NewFilmMatcher(), fd.app.WatchedFilms)
+	if len(preds) > 0 {
+		titles := make([]string, len(preds))
+		for i, p := range preds {
+			titles[i] = p.Title
+		}
+		b.WriteString(fmt.Sprintf("\nWatch first: %s", strings.Join(titles, ", ")))
+	}
+	return b.String()
+}
+
+// Where the film can be watched in the configured region (services the user
+// subscribes to are highlighted).
+func (fd *FilmDetailsModel) providersText() string {
+	wp, ok := fd.film.WatchProviders()
+	if !ok {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(filmCastHeaderStyle.Render(fmt.Sprintf("Where to Watch (%s)", wp.Region)))
+	for _, line := range []struct {
+		label     string
+		providers []string
+	}{{"Stream", wp.Flatrate}, {"Rent", wp.Rent}, {"Buy", wp.Buy}} {
+		if len(line.providers) == 0 {
+			continue
+		}
+		names := make([]string, len(line.providers))
+		for i, p := range line.providers {
+			names[i] = p
+			if line.label == "Stream" && app.IsMyService(p) {
+				names[i] = filmMyServiceStyle.Render(p)
+			}
+		}
+		b.WriteString(fmt.Sprintf("\n%s: %s", line.label, strings.Join(names, ", ")))
+	}
+	if len(wp.Flatrate)+len(wp.Rent)+len(wp.Buy) == 0 {
+		b.WriteString("\nNot available")
+	}
+	return b.String()
+}
+
+// Notice shown when details are out of date (e.g., when offline).
+func (fd *FilmDetailsModel) staleLine() string {
+	if fd.film.Checked.IsZero() || !fd.film.Expired() {
+		return ""
+	}
+	return filmStaleStyle.Render(fmt.Sprintf("saved details from %s", fd.film.Checked.Format("Jan 2, 2006")))
+}
+
+// Describes what the user thought of the film (e.g., "You rated this ★★★½ ♥").
+func (fd *FilmDetailsModel) ratingLine() string {
+	r, ok := fd.app.Rating(fd.film.Film)
+	if !ok {
+		return ""
+	}
+	parts := make([]string, 0, 3)
+	if stars := r.StarString(); stars != "" {
+		parts = append(parts, "You rated this "+stars)
+	}
+	if r.Liked {
+		parts = append(parts, "\u2665")
+	}
+	if r.Rewatched {
+		parts = append(parts, "(rewatched)")
+	}
+	return strings.Join(parts, " ")
+}
+
 func (fd *FilmDetailsModel) castLine(limit int) string {
 	cast := fd.film.Details.Credits.Cast
 	names := make([]string, 0, limit)
@@ -185,6 +384,48 @@ func filmActions(fr app.FilmRecord, a *ApplicationTUI) []FilmAction {
 			}
 		}},
 	}
+	if _, ok := fr.Trailer(); ok {
+		actions = append(actions, FilmAction{
+			label: "Trailer",
+			action: func(f app.FilmRecord) (tea.Cmd, error) {
+				t, _ := f.Trailer()
+				if app.Config.Features.TrailerPlayer == "" {
+					return launchBrowserCmd(t.Url), nil
+				}
+				if err := app.PlayTrailer(t); err != nil {
+					return statusMessageCmd(Message{text: fmt.Sprintf("error %s", err), error: true}), err
+				}
+				return statusMessageCmd(Message{text: fmt.Sprintf("Playing %s", t.Name)}), nil
+			},
+		})
+	}
+	if len(fr.Credits()) > 0 {
+		actions = append(actions, FilmAction{
+			label: "People",
+			action: func(f app.FilmRecord) (tea.Cmd, error) {
+				a.screens.push(MakeCreditsScreen(f, a))
+				return nil, nil
+			},
+		})
+	}
+	if fr.TMDBID != 0 {
+		actions = append(actions, FilmAction{
+			label: "Images",
+			action: func(f app.FilmRecord) (tea.Cmd, error) {
+				is, cmd := MakeImagesScreen(f, a)
+				a.screens.push(is)
+				return cmd, nil
+			},
+		})
+		actions = append(actions, FilmAction{
+			label: "Similar",
+			action: func(f app.FilmRecord) (tea.Cmd, error) {
+				sf, cmd := MakeSimilarFilms(f, a)
+				a.screens.push(sf)
+				return cmd, nil
+			},
+		})
+	}
 	if fr.Url != "" {
 		actions = append(actions, FilmAction{
 			label:  "Letterboxd",
@@ -212,12 +453,13 @@ func MakeFilmDetailsModel(f *app.Film, a *ApplicationTUI) *FilmDetailsModel {
 		actions = filmActions(*fr, a)
 	}
 	return &FilmDetailsModel{
-		film:    fr,
-		focused: false,
-		style:   filmDetailsStyle.BorderForeground(focusedColor),
-		app:     a,
-		actions: actions,
-		err:     err,
+		film:     fr,
+		focused:  false,
+		style:    filmDetailsStyle.BorderForeground(focusedColor),
+		app:      a,
+		actions:  actions,
+		viewport: viewport.New(0, 0),
+		err:      err,
 	}
 }
 
@@ -230,11 +472,12 @@ func MakeFilmDetailsModelFromResults(f tmdb.MovieResult, a *ApplicationTUI) *Fil
 		Details: details,
 	}
 	return &FilmDetailsModel{
-		film:    &fr,
-		focused: false,
-		style:   filmDetailsStyle.BorderForeground(focusedColor),
-		app:     a,
-		actions: filmActions(fr, a),
-		err:     err,
+		film:     &fr,
+		focused:  false,
+		style:    filmDetailsStyle.BorderForeground(focusedColor),
+		app:      a,
+		actions:  filmActions(fr, a),
+		viewport: viewport.New(0, 0),
+		err:      err,
 	}
 }
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"log"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+// Screen listing films from TMDB (e.g., similar films or a person's
+// filmography), optionally with some text above them. Results can be added to
+// local lists.
+type FilmResultsScreen struct {
+	ListSelector
+	header string // text shown above results
+}
+
+type filmResultsMsg struct {
+	title  string
+	header string
+	items  []list.Item
+}
+
+func (fs *FilmResultsScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case filmResultsMsg:
+		fs.list.Title = msg.title
+		fs.header = msg.header
+		if fs.header != "" {
+			fs.list.SetHeight(paneHeight - lipgloss.Height(fs.header))
+		}
+		return fs, fs.list.SetItems(msg.items)
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Back):
+			return fs, GoBack
+		case key.Matches(msg, keys.AddList):
+			if r, ok := fs.list.SelectedItem().(FilmResultItem); ok {
+				fs.app.screens.push(MakeAddToLocalList(fs.app, r))
+			}
+			return fs, nil
+		}
+	}
+	var cmd tea.Cmd
+	fs.list, cmd = fs.list.Update(msg)
+	return fs, cmd
+}
+
+func (fs *FilmResultsScreen) View() string {
+	if fs.header == "" {
+		return fs.ListSelector.View()
+	}
+	return fs.style.Width(paneWidth).Height(paneHeight).
+		Render(lipgloss.JoinVertical(lipgloss.Left, fs.header, fs.list.View()))
+}
+
+// Makes results screen, along with the command that loads its results.
+func MakeFilmResultsScreen(a *ApplicationTUI, loadingTitle string, load func() (filmResultsMsg, error)) (*FilmResultsScreen, tea.Cmd) {
+	ls := MakeListSelector(a, loadingTitle, nil, newFilmSearchDelegate(a))
+	ls.list.SetStatusBarItemName("film", "films")
+	ls.Focus()
+	return &FilmResultsScreen{ListSelector: *ls}, func() tea.Msg {
+		msg, err := load()
+		if err != nil {
+			log.Print(err)
+			return statusMessageMsg{message: Message{text: err.Error(), error: true}}
+		}
+		return msg
+	}
+}
+
+func filmResultItems(results []tmdb.MovieResult) []list.Item {
+	items := make([]list.Item, len(results))
+	for i, r := range results {
+		items[i] = FilmResultItem(r)
+	}
+	return items
+}
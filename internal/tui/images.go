@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jsdoublel/nw/internal/app"
+)
+
+var imageKinds = []app.ImageKind{app.ImagePoster, app.ImageBackdrop, app.ImageLogo}
+
+type imageItem struct{ app.FilmImage }
+
+func (ii imageItem) FilterValue() string { return ii.Language }
+func (ii imageItem) Title() string {
+	lang := ii.Language
+	if lang == "" {
+		lang = "no text"
+	}
+	return fmt.Sprintf("%s · %d×%d", lang, ii.Width, ii.Height)
+}
+func (ii imageItem) Description() string {
+	return fmt.Sprintf("★ %.1f (%d votes)", ii.Rating, ii.Votes)
+}
+
+type imagesMsg struct {
+	kind  app.ImageKind
+	items []list.Item
+}
+
+// Screen listing all of the film's images of one kind on TMDB, downloading
+// the selected one. Left and right switch between posters, backdrops, and
+// logos.
+type ImagesScreen struct {
+	ListSelector
+	film app.FilmRecord
+	kind app.ImageKind
+}
+
+func (is *ImagesScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case imagesMsg:
+		if msg.kind != is.kind { // user switched kinds while loading
+			return is, nil
+		}
+		is.list.Title = fmt.Sprintf("%s %ss", is.film.Title, is.kind)
+		return is, is.list.SetItems(msg.items)
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Back):
+			return is, GoBack
+		case msg.Type == tea.KeyEnter:
+			if ii, ok := is.list.SelectedItem().(imageItem); ok {
+				return is, downloadImageCmd(is.film, ii.FilmImage)
+			}
+			return is, nil
+		case key.Matches(msg, keys.Left):
+			return is, is.switchKind(-1)
+		case key.Matches(msg, keys.Right):
+			return is, is.switchKind(1)
+		}
+	}
+	var cmd tea.Cmd
+	is.list, cmd = is.list.Update(msg)
+	return is, cmd
+}
+
+func (is *ImagesScreen) switchKind(step int) tea.Cmd {
+	i := slices.Index(imageKinds, is.kind) + step
+	is.kind = imageKinds[(i+len(imageKinds))%len(imageKinds)]
+	return is.load()
+}
+
+// Clears the list and loads images of the current kind.
+func (is *ImagesScreen) load() tea.Cmd {
+	is.list.Title = fmt.Sprintf("Loading %ss...", is.kind)
+	is.list.SetStatusBarItemName(string(is.kind), string(is.kind)+"s")
+	kind, tmdbID := is.kind, is.film.TMDBID
+	return tea.Batch(is.list.SetItems(nil), func() tea.Msg {
+		images, err := app.FilmImages(tmdbID, kind)
+		if err != nil {
+			log.Print(err)
+			return statusMessageMsg{message: Message{text: fmt.Sprintf("could not get %ss, %s", kind, err), error: true}}
+		}
+		items := make([]list.Item, len(images))
+		for i, img := range images {
+			items[i] = imageItem{img}
+		}
+		return imagesMsg{kind: kind, items: items}
+	})
+}
+
+func downloadImageCmd(fr app.FilmRecord, img app.FilmImage) tea.Cmd {
+	return func() tea.Msg {
+		path, err := app.DownloadImage(fr, img)
+		if err != nil {
+			log.Print(err)
+			return statusMessageMsg{message: Message{text: fmt.Sprintf("error %s", err), error: true}}
+		}
+		return statusMessageMsg{message: Message{text: fmt.Sprintf("%s downloaded to %s", img.Kind, path)}}
+	}
+}
+
+// Makes image picker screen, starting on the kind of image set in the config,
+// along with the command that loads its images.
+func MakeImagesScreen(fr app.FilmRecord, a *ApplicationTUI) (*ImagesScreen, tea.Cmd) {
+	kind, err := app.ConfigImageKind()
+	if err != nil {
+		log.Print(err)
+		kind = app.ImagePoster
+	}
+	ls := MakeListSelector(a, "", nil, listStyleDelegate())
+	ls.Focus()
+	is := &ImagesScreen{ListSelector: *ls, film: fr, kind: kind}
+	return is, is.load()
+}
@@ -22,6 +22,8 @@ type keyMap struct {
 	Right       key.Binding
 	Up          key.Binding
 	Down        key.Binding
+	ScrollUp    key.Binding
+	ScrollDown  key.Binding
 	MoveLeft    key.Binding
 	MoveRight   key.Binding
 	MoveUp      key.Binding
@@ -31,6 +33,9 @@ type keyMap struct {
 	Update      key.Binding
 	StopWatch   key.Binding
 	About       key.Binding
+	History     key.Binding
+	SwitchQueue key.Binding
+	Posters     key.Binding
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
@@ -43,10 +48,10 @@ func (k keyMap) ShortHelp() []key.Binding {
 
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Left, k.Right, k.Up, k.Down},
+		{k.Left, k.Right, k.Up, k.Down, k.ScrollUp, k.ScrollDown},
 		{k.MoveLeft, k.MoveRight, k.MoveUp, k.MoveDown},
-		{k.Update, k.Delete, k.SearchFilms, k.AddList},
-		{k.About, k.Back, k.Help, k.Quit},
+		{k.Update, k.Delete, k.SearchFilms, k.AddList, k.SwitchQueue, k.Posters},
+		{k.About, k.History, k.Back, k.Help, k.Quit},
 	}
 }
 
@@ -61,6 +66,8 @@ func newKeyMap() keyMap {
 		Right:       binding(app.Config.Keybinds.Right, []string{"right", "l"}, "\u2192/l", "right"),
 		Up:          binding(app.Config.Keybinds.Up, []string{"up", "k"}, "\u2191/k", "up"),
 		Down:        binding(app.Config.Keybinds.Down, []string{"down", "j"}, "\u2193/j", "down"),
+		ScrollUp:    binding(app.Config.Keybinds.ScrollUp, []string{"pgup", "shift+up"}, "pgup", "scroll details up"),
+		ScrollDown:  binding(app.Config.Keybinds.ScrollDown, []string{"pgdown", "shift+down"}, "pgdown", "scroll details down"),
 		MoveLeft:    binding(app.Config.Keybinds.MoveLeft, []string{"ctrl+h"}, "ctrl+h", "move focus left"),
 		MoveRight:   binding(app.Config.Keybinds.MoveRight, []string{"ctrl+l"}, "ctrl+l", "move focus right"),
 		MoveUp:      binding(app.Config.Keybinds.MoveUp, []string{"ctrl+k"}, "ctrl+k", "move focus up"),
@@ -74,6 +81,9 @@ func newKeyMap() keyMap {
 		Update:      binding(app.Config.Keybinds.Update, []string{"ctrl+u"}, "ctrl+u", "update data"),
 		StopWatch:   binding(app.Config.Keybinds.StopWatch, []string{"ctrl+w"}, "ctrl+w", "stop watching"),
 		About:       binding(app.Config.Keybinds.About, []string{"ctrl+a"}, "ctrl+a", "about"),
+		History:     binding(app.Config.Keybinds.History, []string{"ctrl+r"}, "ctrl+r", "list changes"),
+		SwitchQueue: binding(app.Config.Keybinds.SwitchQueue, []string{"tab"}, "tab", "switch queue"),
+		Posters:     binding(app.Config.Keybinds.Posters, []string{"ctrl+p"}, "ctrl+p", "download posters"),
 	}
 }
 
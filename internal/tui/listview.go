@@ -102,12 +102,39 @@ func (li viewListItem) Description() string {
 		suffix = "List Empty"
 	case errors.Is(err, app.ErrNoValidFilm):
 		suffix = "List Complete"
+	case errors.Is(err, app.ErrWaitingOnPredecessors):
+		suffix = "Only Sequels Left"
 	default:
 		suffix = nw.String()
 	}
+	if meta := listMetaString(li.fl); meta != "" {
+		return fmt.Sprintf("%s :: %s :: %s", ordered, suffix, meta)
+	}
 	return fmt.Sprintf("%s :: %s", ordered, suffix)
 }
 
+// Summary of list metadata (e.g., "by oscars, 1204 likes, updated Mar 11,
+// 2024, #oscars #best-picture"). Empty if no metadata is known.
+func listMetaString(fl *app.FilmList) string {
+	parts := make([]string, 0, 5)
+	if fl.Kind != app.KindList {
+		parts = append(parts, fl.Kind.String())
+	}
+	if fl.Owner != "" {
+		parts = append(parts, "by "+fl.Owner)
+	}
+	if fl.Likes > 0 {
+		parts = append(parts, fmt.Sprintf("%d likes", fl.Likes))
+	}
+	if !fl.Updated.IsZero() {
+		parts = append(parts, "updated "+fl.Updated.Local().Format("Jan 2, 2006"))
+	}
+	if len(fl.Tags) > 0 {
+		parts = append(parts, "#"+strings.Join(fl.Tags, " #"))
+	}
+	return strings.Join(parts, ", ")
+}
+
 func (d viewListsDelegate) Update(msg tea.Msg, ls *list.Model) tea.Cmd {
 	switch msg := msg.(type) {
 	case UpdateScreenMsg:
@@ -124,6 +151,8 @@ func (d viewListsDelegate) Update(msg tea.Msg, ls *list.Model) tea.Cmd {
 			d.app.AskYesNo(fmt.Sprintf("Stop tracking list %s?", li.Title()), func(b bool) tea.Msg {
 				return removeListMsg{ok: b}
 			})
+		} else if key.Matches(msg, keys.Posters) {
+			return downloadPostersCmd(d.app, li.fl.Name, li.fl.Films)
 		}
 	case removeListMsg:
 		li, ok := ls.SelectedItem().(viewListItem)
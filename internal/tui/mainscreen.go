@@ -26,6 +26,9 @@ type MainScreen struct {
 }
 
 func (ms *MainScreen) Init() tea.Cmd {
+	if jm, ok := ms.panes[mainScreenNWPos].(*JoinModel); ok {
+		return jm.secondary.Init()
+	}
 	return nil
 }
 
@@ -46,7 +49,7 @@ func (ms *MainScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			ms.app.screens.push(MakeSearchFilms(ms.app))
 		}
 	case NewFilmDetailsMsg:
-		ms.NewFilmDetails(msg.film)
+		cmd = tea.Batch(cmd, ms.NewFilmDetails(msg.film))
 	case UpdateScreenMsg:
 		for _, p := range ms.panes {
 			p.Update(msg)
@@ -82,10 +85,10 @@ func (ms *MainScreen) focusLeft() {
 	ms.panes[ms.focus].Focus()
 }
 
-func (ms *MainScreen) NewFilmDetails(film app.Film) {
+func (ms *MainScreen) NewFilmDetails(film app.Film) tea.Cmd {
 	if jm, ok := ms.panes[mainScreenNWPos].(*JoinModel); ok {
 		jm.secondary = MakeFilmDetailsModel(&film, ms.app)
-		return
+		return jm.secondary.Init()
 	}
 	panic("film details not in correct position in JoinModel")
 }
@@ -26,11 +26,21 @@ type itemTitle interface {
 }
 
 type nwListItem struct {
-	film    *app.Film
-	updated bool
+	film     *app.Film
+	updated  bool
+	wantedBy []string // group members that want to watch film
+}
+
+func (li nwListItem) Title() string {
+	if li.film == nil {
+		return ""
+	}
+	if len(li.wantedBy) > 0 {
+		return fmt.Sprintf("%s [%s]", li.film, strings.Join(li.wantedBy, ", "))
+	}
+	return li.film.String()
 }
 
-func (li nwListItem) Title() string       { return li.film.String() }
 func (li nwListItem) Updated() bool       { return li.updated }
 func (li nwListItem) FilterValue() string { return "" }
 
@@ -40,7 +50,9 @@ func (li stackSeparator) Title() string       { return "" }
 func (li stackSeparator) Updated() bool       { return false }
 func (li stackSeparator) FilterValue() string { return "" }
 
-type nwItemDelegate struct{}
+type nwItemDelegate struct {
+	nw *NWModel
+}
 
 func (d nwItemDelegate) Height() int  { return 1 }
 func (d nwItemDelegate) Spacing() int { return 0 }
@@ -69,7 +81,7 @@ func (d nwItemDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
 	if _, ok := msg.(UpdateScreenMsg); ok {
 		update = true
 	}
-	if li, ok := m.SelectedItem().(nwListItem); update && ok {
+	if li, ok := m.SelectedItem().(nwListItem); update && ok && li.film != nil {
 		return func() tea.Msg { return NewFilmDetailsMsg{film: *li.film} }
 	}
 	return nil
@@ -101,7 +113,7 @@ func (d nwItemDelegate) Render(w io.Writer, m list.Model, index int, listItem li
 	}
 	if index == 0 {
 		b.Reset()
-		b.WriteString(" Next Watch: ")
+		b.WriteString(d.nw.label())
 	}
 	b.WriteString(it.Title())
 	content := trimAndPadString(b.String(), paneWidth)
@@ -114,18 +126,76 @@ type NWModel struct {
 	list    list.Model
 	style   lipgloss.Style
 	focused bool
+	shown   int // queue being shown: zero for user's queue, then group queues, then named queues
 	app     *ApplicationTUI
 }
 
+// Number of queues that can be switched between.
+func (nw *NWModel) numQueues() int {
+	return 1 + len(nw.app.Groups) + len(nw.app.Queues)
+}
+
+// Group whose queue is being shown (nil if not showing a group queue).
+func (nw *NWModel) shownGroup() *app.Group {
+	if nw.shown >= nw.numQueues() { // queue was removed
+		nw.shown = 0
+	}
+	if nw.shown == 0 || nw.shown > len(nw.app.Groups) {
+		return nil
+	}
+	return nw.app.Groups[nw.shown-1]
+}
+
+// Named queue being shown (nil if not showing a named queue).
+func (nw *NWModel) shownQueue() *app.Queue {
+	if nw.shown >= nw.numQueues() {
+		nw.shown = 0
+	}
+	if i := nw.shown - 1 - len(nw.app.Groups); i >= 0 {
+		return nw.app.Queues[i]
+	}
+	return nil
+}
+
+// Queue being shown.
+func (nw *NWModel) queue() *app.NextWatch {
+	if g := nw.shownGroup(); g != nil {
+		return &g.NextWatch
+	}
+	if q := nw.shownQueue(); q != nil {
+		return &q.NextWatch
+	}
+	return &nw.app.NWQueue
+}
+
+// Label in front of the next film in the queue.
+func (nw *NWModel) label() string {
+	if g := nw.shownGroup(); g != nil {
+		return fmt.Sprintf(" %s: ", g.Name)
+	}
+	if q := nw.shownQueue(); q != nil {
+		return fmt.Sprintf(" %s: ", q.Name)
+	}
+	return " Next Watch: "
+}
+
 func (nw *NWModel) Init() tea.Cmd { return nil }
 
 func (nw *NWModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	li, ok := nw.list.SelectedItem().(nwListItem)
-	if !ok {
+	ok = ok && li.film != nil
+	if _, update := msg.(UpdateScreenMsg); !ok && !update { // queue is empty
 		return nil, nil
 	}
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if key.Matches(msg, keys.SwitchQueue) {
+			nw.shown = (nw.shown + 1) % nw.numQueues()
+			return nil, UpdateScreen
+		}
+		if key.Matches(msg, keys.Posters) {
+			return nil, downloadPostersCmd(nw.app, strings.Trim(nw.label(), " :"), nw.queue().Films())
+		}
 		if key.Matches(msg, keys.Delete) {
 			nw.app.AskYesNo(
 				fmt.Sprintf("Remove \"%s\" from queue?\nCannot be undone!", li.film),
@@ -134,13 +204,13 @@ func (nw *NWModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case nwDeleteFilmMsg:
 		if msg.ok {
-			if err := nw.app.NWQueue.DeleteFilm(*li.film); err != nil {
+			if err := nw.queue().DeleteFilm(*li.film); err != nil {
 				log.Printf("error after deleting film, %s", err)
 			}
 			return nil, UpdateScreen
 		}
 	case UpdateScreenMsg:
-		nw.list.SetItems(makeNWItemsList(nw.app))
+		nw.list.SetItems(makeNWItemsList(nw.queue(), nw.shownGroup()))
 	}
 	var cmd tea.Cmd
 	nw.list, cmd = nw.list.Update(msg)
@@ -164,31 +234,39 @@ func (nw *NWModel) Unfocus() {
 }
 
 func MakeNWModel(a *ApplicationTUI) *NWModel {
-	l := list.New(makeNWItemsList(a), nwItemDelegate{}, paneWidth, paneHeight)
-	l.SetFilteringEnabled(false)
-	l.SetShowTitle(false)
-	l.SetShowStatusBar(false)
-	l.SetShowHelp(false)
-	l.SetShowPagination(false)
-	return &NWModel{
-		list:    l,
+	nw := &NWModel{
 		style:   nwStyle.BorderForeground(focusedColor),
 		app:     a,
 		focused: true,
 	}
+	nw.list = list.New(makeNWItemsList(nw.queue(), nil), nwItemDelegate{nw}, paneWidth, paneHeight)
+	nw.list.SetFilteringEnabled(false)
+	nw.list.SetShowTitle(false)
+	nw.list.SetShowStatusBar(false)
+	nw.list.SetShowHelp(false)
+	nw.list.SetShowPagination(false)
+	return nw
 }
 
-func makeNWItemsList(a *ApplicationTUI) []list.Item {
-	items := make([]list.Item, app.StackSize*app.NumberOfStacks+1+app.NumberOfStacks)
-	var count, prevI int
-	for i, j := range a.NWQueue.Positions() {
-		if i != prevI {
-			items[count] = stackSeparator{}
-			prevI = i
-			count++
+// Items for queue. For group queues, items show which members want each film.
+// Empty spots (left when a queue can't be filled) are skipped.
+func makeNWItemsList(queue *app.NextWatch, group *app.Group) []list.Item {
+	items := make([]list.Item, 0, app.StackSize*app.NumberOfStacks+1+app.NumberOfStacks)
+	var prevI int
+	for i, j := range queue.Positions() {
+		film := queue.Stacks[i][j]
+		if film == nil {
+			continue
+		}
+		if i != prevI && len(items) > 0 {
+			items = append(items, stackSeparator{})
+		}
+		prevI = i
+		item := nwListItem{film: film, updated: queue.LastUpdated(i, j)}
+		if group != nil {
+			item.wantedBy = group.WantedBy(*film)
 		}
-		items[count] = nwListItem{film: a.NWQueue.Stacks[i][j], updated: a.NWQueue.LastUpdated(i, j)}
-		count++
+		items = append(items, item)
 	}
 	return items
 }
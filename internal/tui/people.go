@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	tmdb "github.com/cyruzin/golang-tmdb"
+
+	"github.com/jsdoublel/nw/internal/app"
+)
+
+const personBioLines = 6 // lines of biography shown above filmography
+
+type creditItem struct{ app.Credit }
+
+func (ci creditItem) FilterValue() string { return ci.Name }
+func (ci creditItem) Title() string       { return ci.Name }
+func (ci creditItem) Description() string { return ci.Role }
+
+type creditsDelegate struct {
+	list.DefaultDelegate
+	app *ApplicationTUI
+}
+
+func (d creditsDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.Type == tea.KeyEnter {
+		if ci, ok := m.SelectedItem().(creditItem); ok {
+			screen, cmd := MakePersonScreen(ci.Credit, d.app)
+			d.app.screens.push(screen)
+			return cmd
+		}
+	}
+	return nil
+}
+
+// Screen for picking a person from the film's credits.
+type CreditsScreen struct {
+	ListSelector
+}
+
+func (cs *CreditsScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && key.Matches(msg, keys.Back) {
+		return cs, GoBack
+	}
+	var cmd tea.Cmd
+	cs.list, cmd = cs.list.Update(msg)
+	return cs, cmd
+}
+
+func MakeCreditsScreen(fr app.FilmRecord, a *ApplicationTUI) *CreditsScreen {
+	credits := fr.Credits()
+	items := make([]list.Item, len(credits))
+	for i, c := range credits {
+		items[i] = creditItem{c}
+	}
+	ls := MakeListSelector(a, fmt.Sprintf("People in %s", fr.Title), items, creditsDelegate{listStyleDelegate(), a})
+	ls.list.SetStatusBarItemName("person", "people")
+	ls.Focus()
+	return &CreditsScreen{ListSelector: *ls}
+}
+
+// Screen showing person's biography and filmography, marking films the user
+// has watched.
+func MakePersonScreen(c app.Credit, a *ApplicationTUI) (*FilmResultsScreen, tea.Cmd) {
+	return MakeFilmResultsScreen(a, fmt.Sprintf("Loading %s...", c.Name), func() (filmResultsMsg, error) {
+		p, err := app.TMDBPerson(c.PersonID)
+		if err != nil {
+			return filmResultsMsg{}, fmt.Errorf("could not get details for %s, %w", c.Name, err)
+		}
+		results := make([]tmdb.MovieResult, len(p.Films))
+		for i, pf := range p.Films {
+			results[i] = pf.MovieResult
+		}
+		watched, released := a.WatchedProgress(results, a.NewFilmMatcher())
+		return filmResultsMsg{
+			title:  fmt.Sprintf("Filmography: watched %d of %d", watched, released),
+			header: personHeader(p),
+			items:  filmResultItems(results),
+		}, nil
+	})
+}
+
+func personHeader(p *app.Person) string {
+	var b strings.Builder
+	b.WriteString(filmTitleStyle.Render(p.Name))
+	var facts []string
+	if p.KnownFor != "" {
+		facts = append(facts, p.KnownFor)
+	}
+	if p.Born != "" {
+		facts = append(facts, "born "+p.Born)
+	}
+	if p.Died != "" {
+		facts = append(facts, "died "+p.Died)
+	}
+	if len(facts) > 0 {
+		b.WriteString("\n")
+		b.WriteString(strings.Join(facts, ", "))
+	}
+	if p.Biography != "" {
+		frameW, _ := lsStyle.GetFrameSize()
+		bio := strings.Split(filmTextStyle.Width(paneWidth-frameW).Render(p.Biography), "\n")
+		if len(bio) > personBioLines {
+			bio = bio[:personBioLines]
+			bio[len(bio)-1] = strings.TrimRight(bio[len(bio)-1], " ") + string(ellipse)
+		}
+		b.WriteString("\n\n")
+		b.WriteString(strings.Join(bio, "\n"))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -1,12 +1,20 @@
 package tui
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	overlay "github.com/rmhubbert/bubbletea-overlay"
+
+	"github.com/jsdoublel/nw/internal/app"
 )
 
+const historyPopupLen = 10 // number of list changes shown in history popup
+
 type PopupModel struct {
 	text string
 }
@@ -30,3 +38,19 @@ func (a *ApplicationTUI) Popup(text string) {
 	overlayModel := overlay.New(popup, backdrop, overlay.Center, overlay.Center, 0, 0)
 	a.screens.push(overlayModel)
 }
+
+// Text for popup listing the most recent changes to tracked lists.
+func historyText(history []app.ListDiff) string {
+	if len(history) == 0 {
+		return "No changes to tracked lists yet"
+	}
+	lines := make([]string, 0, historyPopupLen+1)
+	lines = append(lines, "Recent list changes\n")
+	for _, diff := range slices.Backward(history) {
+		lines = append(lines, fmt.Sprintf("%s  %s", diff.Time.Format("Jan 2"), diff))
+		if len(lines) > historyPopupLen {
+			break
+		}
+	}
+	return strings.Join(lines, "\n")
+}
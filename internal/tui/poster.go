@@ -0,0 +1,313 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/jsdoublel/nw/internal/app"
+)
+
+type posterProtocol int
+
+const (
+	posterNone   posterProtocol = iota
+	posterBlocks                // half-block characters colored with the poster
+	posterKitty                 // kitty graphics protocol (unicode placeholders)
+	posterSixel                 // sixel graphics
+
+	posterCols = 16 // width of posters in cells
+	posterRows = 12 // height of posters in cells (posters are 2:3, cells about 1:2)
+
+	// assumed cell size in pixels for sixel images (sixel has no way of
+	// scaling to cells)
+	sixelCellWidth  = 10
+	sixelCellHeight = 20
+
+	kittyChunkSize   = 4096
+	kittyPlaceholder = "\U0010EEEE"
+)
+
+// Diacritics encoding rows and columns of kitty placeholders (the first few
+// from kitty's rowcolumn-diacritics.txt).
+var kittyDiacritics = []rune{
+	0x0305, 0x030D, 0x030E, 0x0310, 0x0312, 0x033D, 0x033E, 0x033F,
+	0x0346, 0x034A, 0x034B, 0x034C, 0x0350, 0x0351, 0x0352, 0x0357,
+}
+
+var posterProto = detectPosterProtocol()
+
+// Picks how posters are drawn from the config, or from what the terminal
+// supports when set to auto.
+func detectPosterProtocol() posterProtocol {
+	switch strings.ToLower(app.Config.Appearance.Posters) {
+	case "none", "off":
+		return posterNone
+	case "blocks":
+		return posterBlocks
+	case "kitty":
+		return posterKitty
+	case "sixel":
+		return posterSixel
+	}
+	term, program := os.Getenv("TERM"), os.Getenv("TERM_PROGRAM")
+	switch {
+	case lipgloss.ColorProfile() == termenv.Ascii:
+		return posterNone
+	case os.Getenv("TMUX") != "" || strings.HasPrefix(term, "screen"): // graphics need passthrough
+		return posterBlocks
+	case os.Getenv("KITTY_WINDOW_ID") != "" || term == "xterm-kitty" || program == "ghostty":
+		return posterKitty
+	case program == "WezTerm" || program == "iTerm.app" || strings.HasPrefix(term, "foot") ||
+		strings.Contains(term, "mlterm") || strings.Contains(term, "contour") || strings.Contains(term, "sixel"):
+		return posterSixel
+	}
+	return posterBlocks
+}
+
+// Sent when a poster has been rendered (so the view is redrawn).
+type posterLoadedMsg struct{}
+
+// Rendered posters indexed by TMDB poster path. Empty if the poster could not
+// be loaded.
+var posters = struct {
+	sync.Mutex
+	rendered map[string]string
+	nextID   uint32 // next kitty image id
+}{rendered: make(map[string]string), nextID: 1}
+
+// Loads and renders the film's poster in the background if it has not been
+// already.
+func posterCmd(fr *app.FilmRecord) tea.Cmd {
+	if posterProto == posterNone || fr == nil || fr.Details == nil || fr.Details.PosterPath == "" {
+		return nil
+	}
+	key := fr.Details.PosterPath
+	posters.Lock()
+	_, ok := posters.rendered[key]
+	posters.Unlock()
+	if ok {
+		return nil
+	}
+	return func() tea.Msg {
+		rendered := ""
+		if img, err := app.PosterThumbnail(*fr); err != nil {
+			log.Printf("could not load poster for %s, %s", fr.Film, err)
+		} else {
+			rendered = renderPoster(img)
+		}
+		posters.Lock()
+		posters.rendered[key] = rendered
+		posters.Unlock()
+		return posterLoadedMsg{}
+	}
+}
+
+// Rendered poster for film, blank space while it is loading, or an empty
+// string if there is no poster.
+func posterView(fr *app.FilmRecord) string {
+	if posterProto == posterNone || fr.Details == nil || fr.Details.PosterPath == "" {
+		return ""
+	}
+	posters.Lock()
+	rendered, ok := posters.rendered[fr.Details.PosterPath]
+	posters.Unlock()
+	if !ok {
+		return blankPoster()
+	}
+	return rendered
+}
+
+func blankPoster() string {
+	return strings.TrimSuffix(strings.Repeat(strings.Repeat(" ", posterCols)+"\n", posterRows), "\n")
+}
+
+func renderPoster(img image.Image) string {
+	switch posterProto {
+	case posterKitty:
+		posters.Lock()
+		id := posters.nextID
+		posters.nextID++
+		posters.Unlock()
+		return kittyPoster(img, id)
+	case posterSixel:
+		return sixelPoster(img)
+	}
+	return blocksPoster(img)
+}
+
+// ----- Half-block posters
+
+// Each cell shows two pixels, the top one as the foreground of "▀" and the
+// bottom one as the background.
+func blocksPoster(img image.Image) string {
+	small := resizeImage(img, posterCols, 2*posterRows)
+	lines := make([]string, posterRows)
+	for y := range posterRows {
+		var b strings.Builder
+		for x := range posterCols {
+			style := lipgloss.NewStyle().
+				Foreground(hexColor(small.RGBAAt(x, 2*y))).
+				Background(hexColor(small.RGBAAt(x, 2*y+1)))
+			b.WriteString(style.Render("▀"))
+		}
+		lines[y] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func hexColor(c color.RGBA) lipgloss.Color {
+	return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B))
+}
+
+// ----- Kitty posters
+
+// Transmits the image as a virtual placement and fills the poster's cells with
+// unicode placeholders referencing it, so the terminal draws the image wherever
+// the placeholders end up in the layout.
+func kittyPoster(img image.Image, id uint32) string {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		log.Printf("could not encode poster, %s", err)
+		return ""
+	}
+	data := base64.StdEncoding.EncodeToString(buf.Bytes())
+	var b strings.Builder
+	for i := 0; i < len(data); i += kittyChunkSize {
+		chunk := data[i:min(i+kittyChunkSize, len(data))]
+		more := 0
+		if i+kittyChunkSize < len(data) {
+			more = 1
+		}
+		if i == 0 {
+			fmt.Fprintf(&b, "\x1b_Ga=T,U=1,f=100,q=2,i=%d,c=%d,r=%d,m=%d;%s\x1b\\", id, posterCols, posterRows, more, chunk)
+		} else {
+			fmt.Fprintf(&b, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+	color := fmt.Sprintf("\x1b[38;2;%d;%d;%dm", (id>>16)&0xff, (id>>8)&0xff, id&0xff) // image id as foreground
+	for y := range posterRows {
+		if y > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(color)
+		b.WriteString(kittyPlaceholder)
+		b.WriteRune(kittyDiacritics[y])
+		b.WriteRune(kittyDiacritics[0])
+		b.WriteString(strings.Repeat(kittyPlaceholder, posterCols-1)) // columns follow on from first cell
+		b.WriteString("\x1b[39m")
+	}
+	return b.String()
+}
+
+// ----- Sixel posters
+
+// Poster is blank space with the sixel image drawn over it from the last line
+// (after the lines above have been drawn), restoring the cursor afterwards so
+// the rest of the layout is not moved.
+func sixelPoster(img image.Image) string {
+	lines := strings.Split(blankPoster(), "\n")
+	lines[len(lines)-1] += fmt.Sprintf("\x1b7\x1b[%dD\x1b[%dA%s\x1b8",
+		posterCols, posterRows-1, encodeSixel(resizeImage(img, posterCols*sixelCellWidth, posterRows*sixelCellHeight)))
+	return strings.Join(lines, "\n")
+}
+
+// Encodes image as sixel using a 6x6x6 color cube palette.
+func encodeSixel(img *image.RGBA) string {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	var b strings.Builder
+	fmt.Fprintf(&b, "\x1bPq\"1;1;%d;%d", w, h)
+	for i := range 216 {
+		fmt.Fprintf(&b, "#%d;2;%d;%d;%d", i, (i/36)*20, (i/6%6)*20, (i%6)*20)
+	}
+	indexes := make([]int, w*h)
+	for y := range h {
+		for x := range w {
+			c := img.RGBAAt(x, y)
+			indexes[y*w+x] = cubeLevel(c.R)*36 + cubeLevel(c.G)*6 + cubeLevel(c.B)
+		}
+	}
+	for y0 := 0; y0 < h; y0 += 6 {
+		used := make([]int, 0)
+		for y := y0; y < min(y0+6, h); y++ {
+			for x := range w {
+				if !slices.Contains(used, indexes[y*w+x]) {
+					used = append(used, indexes[y*w+x])
+				}
+			}
+		}
+		for _, c := range used {
+			fmt.Fprintf(&b, "#%d", c)
+			run, last := 0, byte(0)
+			flush := func() {
+				switch {
+				case run > 3:
+					fmt.Fprintf(&b, "!%d%c", run, last)
+				case run > 0:
+					b.WriteString(strings.Repeat(string(last), run))
+				}
+			}
+			for x := range w {
+				bits := 0
+				for dy := range min(6, h-y0) {
+					if indexes[(y0+dy)*w+x] == c {
+						bits |= 1 << dy
+					}
+				}
+				ch := byte(63 + bits)
+				if ch != last {
+					flush()
+					run, last = 0, ch
+				}
+				run++
+			}
+			flush()
+			b.WriteString("$")
+		}
+		b.WriteString("-")
+	}
+	b.WriteString("\x1b\\")
+	return b.String()
+}
+
+func cubeLevel(v uint8) int {
+	return (int(v)*5 + 127) / 255
+}
+
+// ----- Resizing
+
+// Scales image to w by h pixels, averaging the pixels covered by each new
+// pixel.
+func resizeImage(img image.Image, w, h int) *image.RGBA {
+	src := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		sy0 := src.Min.Y + y*src.Dy()/h
+		sy1 := max(sy0+1, src.Min.Y+(y+1)*src.Dy()/h)
+		for x := range w {
+			sx0 := src.Min.X + x*src.Dx()/w
+			sx1 := max(sx0+1, src.Min.X+(x+1)*src.Dx()/w)
+			var r, g, b, n uint32
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					cr, cg, cb, _ := img.At(sx, sy).RGBA()
+					r, g, b, n = r+cr>>8, g+cg>>8, b+cb>>8, n+1
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: 255})
+		}
+	}
+	return dst
+}
@@ -43,7 +43,28 @@ func (fi FilmResultItem) String() string {
 	return b.String()
 }
 
-type filmSearchDelegate struct{ app *ApplicationTUI }
+type filmSearchDelegate struct {
+	app   *ApplicationTUI
+	known *app.FilmMatcher // matches results to user's films (for marking watched films, etc.)
+}
+
+func newFilmSearchDelegate(a *ApplicationTUI) filmSearchDelegate {
+	return filmSearchDelegate{app: a, known: a.NewFilmMatcher()}
+}
+
+// Marks result if the user has watched it or it is on their watchlist.
+func (d filmSearchDelegate) status(fi FilmResultItem) string {
+	f, ok := d.known.Match(tmdb.MovieResult(fi))
+	switch {
+	case !ok:
+		return ""
+	case d.app.WatchedFilms.InSet(&f):
+		return " \u2713 watched"
+	case d.app.Watchlist.InSet(&f):
+		return " + watchlist"
+	}
+	return ""
+}
 
 func (d filmSearchDelegate) Height() int  { return 1 }
 func (d filmSearchDelegate) Spacing() int { return 0 }
@@ -53,7 +74,7 @@ func (d filmSearchDelegate) Render(w io.Writer, m list.Model, index int, listIte
 	if !ok {
 		panic(fmt.Sprintf("item (type %T) in film results is not FilmResultsItem", li))
 	}
-	out := trimAndPadString(li.String(), paneWidth)
+	out := trimAndPadString(li.String()+d.status(li), paneWidth)
 	if index == m.Index() {
 		out = filmSearchSelectedStyle.Render(out)
 	} else {
@@ -70,8 +91,9 @@ func (d filmSearchDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
 		return nil
 	}
 	if r, ok := m.SelectedItem().(FilmResultItem); ok {
-		d.app.screens.push(MakeFilmDetailsModelFromResults(tmdb.MovieResult(r), d.app))
-		return nil
+		fd := MakeFilmDetailsModelFromResults(tmdb.MovieResult(r), d.app)
+		d.app.screens.push(fd)
+		return fd.Init()
 	}
 	panic(fmt.Sprintf("Film search result (type %T) is not a tmdb.MovieResult", m.SelectedItem()))
 }
@@ -102,16 +124,14 @@ func MakeSearchFilms(a *ApplicationTUI) *SearchFilms {
 				log.Print(err)
 				return statusMessageMsg{message: Message{text: text, error: true}}
 			}
-			items := make([]list.Item, len(results))
-			for i, r := range results {
-				items[i] = FilmResultItem(r)
-			}
-			return UpdateSearchItemsMsg{items: items, query: query}
+			return UpdateSearchItemsMsg{items: filmResultItems(results), query: query}
 		}
 	}
 	EnterAction := func(s string, item list.Item) tea.Cmd {
 		if r, ok := item.(FilmResultItem); ok {
-			a.screens.push(MakeFilmDetailsModelFromResults(tmdb.MovieResult(r), a))
+			fd := MakeFilmDetailsModelFromResults(tmdb.MovieResult(r), a)
+			a.screens.push(fd)
+			return fd.Init()
 		}
 		return nil
 	}
@@ -119,7 +139,7 @@ func MakeSearchFilms(a *ApplicationTUI) *SearchFilms {
 		a,
 		make([]list.Item, 0),
 		"Search films...",
-		filmSearchDelegate{a},
+		newFilmSearchDelegate(a),
 		searchMode,
 		inputAction,
 		EnterAction,
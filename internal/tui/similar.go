@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jsdoublel/nw/internal/app"
+)
+
+// Screen listing films recommended for (or similar to) a film.
+func MakeSimilarFilms(fr app.FilmRecord, a *ApplicationTUI) (*FilmResultsScreen, tea.Cmd) {
+	return MakeFilmResultsScreen(a, fmt.Sprintf("Finding films similar to %s...", fr.Title), func() (filmResultsMsg, error) {
+		results, err := app.SimilarFilms(fr.TMDBID)
+		if err != nil {
+			return filmResultsMsg{}, fmt.Errorf("could not get films similar to %s, %w", fr.Film, err)
+		}
+		return filmResultsMsg{title: fmt.Sprintf("Similar to %s", fr.Title), items: filmResultItems(results)}, nil
+	})
+}
+
+// ----- Add film to local list
+
+type localListItem struct{ fl *app.FilmList }
+
+func (li localListItem) FilterValue() string { return li.fl.Name }
+func (li localListItem) Title() string       { return li.fl.Name }
+func (li localListItem) Description() string { return fmt.Sprintf("%d films", len(li.fl.Films)) }
+
+type newLocalListItem struct{ name string }
+
+func (li newLocalListItem) FilterValue() string { return li.name }
+func (li newLocalListItem) Title() string       { return fmt.Sprintf("New list \"%s\"", li.name) }
+func (li newLocalListItem) Description() string { return "make a list kept in nw" }
+
+type localListsDelegate struct {
+	list.DefaultDelegate
+	add func(list.Item) tea.Cmd
+}
+
+func (d localListsDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.Type == tea.KeyEnter {
+		return d.add(m.SelectedItem())
+	}
+	return nil
+}
+
+// Local lists with names containing query, preceded by an item for making a
+// new list named query (unless a list with that name exists).
+func localListItems(a *ApplicationTUI, query string) []list.Item {
+	items := make([]list.Item, 0)
+	exists := false
+	for _, fl := range a.LocalLists() {
+		if strings.Contains(strings.ToLower(fl.Name), strings.ToLower(query)) {
+			items = append(items, localListItem{fl})
+		}
+		exists = exists || strings.EqualFold(fl.Name, query)
+	}
+	if query != "" && !exists {
+		items = append([]list.Item{newLocalListItem{query}}, items...)
+	}
+	return items
+}
+
+// Film found from a search result, to be added to a local list on the update
+// loop.
+type addToLocalListMsg struct {
+	name   string
+	film   app.Film
+	tmdbID int
+}
+
+func addToLocalListCmd(a *ApplicationTUI, msg addToLocalListMsg) tea.Cmd {
+	if err := a.AddTMDBFilmToLocalList(msg.name, msg.film, msg.tmdbID); err != nil {
+		log.Printf("could not add %s to %s, %s", msg.film, msg.name, err)
+		return statusMessageCmd(Message{text: err.Error(), error: true})
+	}
+	return tea.Batch(statusMessageCmd(Message{text: fmt.Sprintf("Added %s to %s", msg.film, msg.name)}), UpdateScreen)
+}
+
+// Screen for picking the local list to add a film to (or naming a new one).
+func MakeAddToLocalList(a *ApplicationTUI, film FilmResultItem) *SearchModel {
+	add := func(item list.Item) tea.Cmd {
+		var name string
+		switch li := item.(type) {
+		case localListItem:
+			name = li.fl.Name
+		case newLocalListItem:
+			name = li.name
+		default:
+			return nil
+		}
+		tmdbID := int(film.ID)
+		if f, ok := a.FilmStore.TMDBIndex()[tmdbID]; ok {
+			return tea.Batch(GoBack, func() tea.Msg { return addToLocalListMsg{name: name, film: f, tmdbID: tmdbID} })
+		}
+		return tea.Batch(GoBack, func() tea.Msg {
+			f, err := app.ScrapeFilmFromTMDB(tmdbID)
+			if err != nil {
+				log.Printf("could not find %s on letterboxd, %s", film, err)
+				return statusMessageMsg{message: Message{text: fmt.Sprintf("could not find film on letterboxd, %s", err), error: true}}
+			}
+			return addToLocalListMsg{name: name, film: f, tmdbID: tmdbID}
+		})
+	}
+	inputAction := func(s string) tea.Cmd {
+		items := localListItems(a, s)
+		return func() tea.Msg { return UpdateSearchItemsMsg{items: items, query: s} }
+	}
+	return MakeSearchModel(
+		a,
+		localListItems(a, ""),
+		fmt.Sprintf("Add %s to list...", film),
+		localListsDelegate{DefaultDelegate: listStyleDelegate(), add: add},
+		searchMode,
+		inputAction,
+		func(_ string, item list.Item) tea.Cmd { return add(item) },
+	)
+}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -8,6 +9,8 @@ import (
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jsdoublel/nw/internal/app"
 )
 
 const SplashText = " NW Loading...  "
@@ -36,17 +39,37 @@ func (ss *SplashScreenModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (ss *SplashScreenModel) View() string {
 	if ss.err != nil {
-		return lipgloss.NewStyle().Foreground(red).Render(lipgloss.JoinVertical(
-			lipgloss.Center,
-			fmt.Sprintf("error %s", ss.err),
-			fmt.Sprintf("press %s to exit.", keys.Back.Help().Key),
-		))
+		lines := []string{fmt.Sprintf("error %s", ss.err)}
+		if hint := errorHint(ss.err); hint != "" {
+			lines = append(lines, hint)
+		}
+		lines = append(lines, fmt.Sprintf("press %s to exit.", keys.Back.Help().Key))
+		return lipgloss.NewStyle().Foreground(red).Render(lipgloss.JoinVertical(lipgloss.Center, lines...))
 	}
 	ss.tick++
 	ss.spinner.Style = splashSpinnerStyles[ss.tick%len(splashSpinnerStyles)]
 	return fmt.Sprintf("%s%s", SplashText[:min(len(SplashText)-1, ss.tick)], ss.spinner.View())
 }
 
+// Suggestion for what to do about a failed scrape, based on its cause.
+func errorHint(err error) string {
+	switch {
+	case errors.Is(err, app.ErrRateLimited):
+		return "letterboxd is rate limiting requests, wait a few minutes and try again."
+	case errors.Is(err, app.ErrPageNotFound):
+		return "letterboxd page not found, check that the username is correct."
+	case errors.Is(err, app.ErrPrivate):
+		return "letterboxd page is private or access was blocked."
+	case errors.Is(err, app.ErrServerError):
+		return "letterboxd is having problems, try again later."
+	case errors.Is(err, app.ErrMarkupChanged):
+		return "letterboxd may have changed its pages, nw may need to be updated."
+	case errors.Is(err, app.ErrNetwork):
+		return "could not reach letterboxd, check your connection or try -offline."
+	}
+	return ""
+}
+
 func (ss *SplashScreenModel) SetError(err error) {
 	ss.err = err
 }
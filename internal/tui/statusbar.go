@@ -2,10 +2,14 @@ package tui
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jsdoublel/nw/internal/app"
 )
 
 const messageTimeout = 5 * time.Second
@@ -23,30 +27,51 @@ type statusEntry struct {
 type StatusBarModel struct {
 	messages []statusEntry
 	nextId   int
+	posters  map[string]postersProgressMsg // bulk poster downloads in progress by list name
 	app      *ApplicationTUI
 }
 
 func (sb *StatusBarModel) Init() tea.Cmd { return nil }
 
 func (sb *StatusBarModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if msg, ok := msg.(statusClearMsg); ok {
+	switch msg := msg.(type) {
+	case statusClearMsg:
 		for i, entry := range sb.messages {
 			if entry.id == msg.id {
 				sb.messages = append(sb.messages[:i], sb.messages[i+1:]...)
 				break
 			}
 		}
+	case postersProgressMsg:
+		sb.posters[msg.name] = msg
+		return sb, waitForPostersCmd(msg.updates)
+	case postersDoneMsg:
+		delete(sb.posters, msg.name)
+		return sb, sb.setMessage(postersSummary(msg))
 	}
 	return sb, nil
 }
 
 func (sb *StatusBarModel) View() string {
 	strs := make([]string, 0)
+	if app.Offline() {
+		text := fmt.Sprintf("Offline, showing saved data (press %s to retry)", keys.Update.Help().Key)
+		if app.OfflineForced() {
+			text = "Offline mode, showing saved data"
+		}
+		strs = append(strs, statusBarOfflineStyle.Render(text))
+	}
 	if sb.app.DiscordRPC.Watching() {
 		strs = append(strs, statusBarWatchingStyle.Render(
 			fmt.Sprintf("Watching %s, press %s to stop", sb.app.DiscordRPC, keys.StopWatch.Help().Key),
 		))
 	}
+	for _, name := range slices.Sorted(maps.Keys(sb.posters)) {
+		p := sb.posters[name]
+		strs = append(strs, statusBarMessageStyle.Render(
+			fmt.Sprintf("Downloading posters for %s (%d/%d)", name, p.done, p.total),
+		))
+	}
 	for _, entry := range sb.messages {
 		if entry.message.error {
 			strs = append(strs, statusBarErrStyle.Render(entry.message.text))
@@ -76,6 +101,7 @@ func (sb *StatusBarModel) setMessage(message Message) tea.Cmd {
 func MakeStatusBar(a *ApplicationTUI) *StatusBarModel {
 	return &StatusBarModel{
 		messages: make([]statusEntry, 0),
+		posters:  make(map[string]postersProgressMsg),
 		app:      a,
 	}
 }
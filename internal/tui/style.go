@@ -88,7 +88,12 @@ var (
 	filmTextStyle       = lipgloss.NewStyle().Width(paneWidth).Foreground(textColor)
 	filmTitleStyle      = lipgloss.NewStyle().Inherit(filmTextStyle).Bold(true)
 	flimDirStyle        = lipgloss.NewStyle().Inherit(filmTextStyle).Italic(true)
+	filmStaleStyle      = lipgloss.NewStyle().Inherit(filmTextStyle).Foreground(yellow).Italic(true)
+	filmRatingStyle     = lipgloss.NewStyle().Inherit(filmTextStyle).Foreground(green)
+	filmNoteStyle       = lipgloss.NewStyle().Inherit(filmTextStyle).Italic(true)
+	filmMyServiceStyle  = lipgloss.NewStyle().Inherit(filmTextStyle).Foreground(green)
 	filmCastHeaderStyle = lipgloss.NewStyle().Inherit(filmTextStyle).Underline(true)
+	filmScrollHintStyle = lipgloss.NewStyle().Foreground(focusedColor).Italic(true)
 	filmActionSelected  = lipgloss.NewStyle().
 				Foreground(textDark).
 				Background(focusedButtonColor).
@@ -108,6 +113,11 @@ var (
 				BorderForeground(green).
 				Foreground(green).
 				Padding(0, 1)
+	statusBarOfflineStyle = lipgloss.NewStyle().
+				Border(mainStyle.GetBorderStyle()).
+				BorderForeground(yellow).
+				Foreground(yellow).
+				Padding(0, 1)
 	statusBarMessageStyle = lipgloss.NewStyle().Width(paneWidth).
 				Border(mainStyle.GetBorderStyle()).
 				Padding(0, 1)
@@ -24,7 +24,9 @@ package main
 import (
 	"flag"
 	"fmt"
+	"log"
 	"os"
+	"path/filepath"
 	"runtime/debug"
 
 	"github.com/jsdoublel/nw/internal/app"
@@ -35,6 +37,7 @@ func parseArgs() string {
 	username := flag.String("u", "", "letterboxd username (overrides config)")
 	config := flag.Bool("c", false, "prints expected config path and exits")
 	version := flag.Bool("v", false, "prints version and exits")
+	offline := flag.Bool("offline", false, "skips all network calls and uses saved data only")
 	help := flag.Bool("h", false, "prints this message and exits")
 	flag.Parse()
 	if *config {
@@ -49,9 +52,92 @@ func parseArgs() string {
 		flag.Usage()
 		os.Exit(0)
 	}
+	if *offline {
+		app.ForceOffline()
+	}
+	switch flag.Arg(0) {
+	case "":
+	case "doctor":
+		os.Exit(runDoctor(*username))
+	case "posters":
+		os.Exit(runPosters(*username, flag.Arg(1)))
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %s\n", flag.Arg(0))
+		flag.Usage()
+		os.Exit(2)
+	}
 	return *username
 }
 
+// Probes the scrapers and prints the results. Returns the exit code.
+func runDoctor(username string) int {
+	if username == "" {
+		username = app.Config.Username
+	}
+	code := 0
+	for _, check := range app.Doctor(username) {
+		if check.Err != nil {
+			fmt.Printf("FAIL %-14s %s\n", check.Name, check.Err)
+			code = 1
+		} else {
+			fmt.Printf("ok   %-14s %s\n", check.Name, check.Detail)
+		}
+	}
+	if code != 0 {
+		fmt.Println("some checks failed; if letterboxd changed its pages, nw may need to be updated")
+	}
+	return code
+}
+
+// Downloads posters for the films in a list or queue without starting the
+// TUI, printing progress and any failures. Returns the exit code.
+func runPosters(username, name string) int {
+	if name == "" {
+		fmt.Fprintln(os.Stderr, "usage: nw posters <list> (watchlist, queue, or the name or url of a tracked list, group, or named queue)")
+		return 2
+	}
+	if logf, err := os.OpenFile(filepath.Join(app.NWDataPath, "nw.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644); err == nil {
+		log.SetOutput(logf)
+		defer func() { _ = logf.Close() }()
+	}
+	if err := app.GetUser(&username, nil); err != nil {
+		fmt.Fprintf(os.Stderr, "nw failed with error: %s\n", err)
+		return 1
+	}
+	application, err := app.Load(username)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "could not load application data, %s\n", err)
+		return 1
+	}
+	defer application.Shutdown()
+	if application.ApiKey == "" {
+		fmt.Fprintln(os.Stderr, "no TMDB api key, set api_key in the config or TMDB_API_KEY")
+		return 1
+	}
+	application.ApiInit()
+	films, err := application.PosterSource(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "nw failed with error: %s\n", err)
+		return 1
+	}
+	results := application.DownloadPosters(films, func(done, total int) {
+		fmt.Printf("\rdownloading posters %d/%d", done, total)
+	})
+	fmt.Println()
+	if err := application.Save(); err != nil { // keep details fetched for the posters
+		fmt.Fprintf(os.Stderr, "could not save film details, %s\n", err)
+	}
+	downloaded, skipped, failed := app.SummarizePosters(results)
+	for _, r := range failed {
+		fmt.Printf("FAIL %s: %s\n", r.Film, r.Err)
+	}
+	fmt.Printf("%d downloaded, %d already downloaded, %d failed\n", downloaded, skipped, len(failed))
+	if len(failed) > 0 {
+		return 1
+	}
+	return 0
+}
+
 func main() {
 	defer func() {
 		if r := recover(); r != nil {